@@ -1,7 +1,6 @@
 package main
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -11,69 +10,103 @@ import (
 	"github.com/yusadeol/go-commit/internal/adapter/cli"
 	"github.com/yusadeol/go-commit/internal/adapter/cli/command"
 
+	"github.com/yusadeol/go-commit/internal/app/usecase"
 	"github.com/yusadeol/go-commit/internal/domain/vo"
 	"github.com/yusadeol/go-commit/internal/infra/service/ai"
+	"github.com/yusadeol/go-commit/internal/infra/service/credential"
+	"github.com/yusadeol/go-commit/internal/infra/service/git"
 )
 
 func main() {
-	args := os.Args[1:]
-	configurationDirPath, err := getConfigurationFilePath()
+	globalOptions, args, err := cli.ParseGlobalOptions(os.Args[1:])
 	if err != nil {
-		exitWithMessage(
-			vo.ExitCodeError,
-			vo.NewMarkupText(fmt.Sprintf("<error>%s</error>", err.Error())),
-		)
+		exitWithError(vo.ExitCodeInvalidUsage, err, &cli.GlobalOptions{Output: cli.OutputText, Color: cli.ColorAuto})
 	}
-	configuration, err := loadConfiguration(configurationDirPath)
+	configurationFilePath, err := getConfigurationFilePath(globalOptions.Config)
 	if err != nil {
-		exitWithMessage(
-			vo.ExitCodeError,
-			vo.NewMarkupText(fmt.Sprintf("<error>%s</error>", err.Error())),
-		)
+		exitWithError(vo.ExitCodeError, err, globalOptions)
 	}
+	repository := git.New("")
+	keyring := credential.NewDefaultKeyring(filepath.Join(filepath.Dir(configurationFilePath), "commit-credentials.json"))
+	credentialResolver := credential.NewDefaultResolver(keyring)
 	commandsToRegister := []dispatcher.Command{
 		command.NewVersion("v1.0.1"),
-		command.NewInit(configurationDirPath),
-		command.NewGenerate(configuration, ai.NewDefaultProviderFactory()),
+		command.NewInit(configurationFilePath, keyring),
+		command.NewConfig(configurationFilePath),
+		command.NewGenerate(ai.NewDefaultProviderFactory(), credentialResolver, repository),
+		command.NewChangelog(ai.NewDefaultProviderFactory(), credentialResolver, repository),
+		command.NewBump(repository),
+		command.NewPullRequest(ai.NewDefaultProviderFactory(), credentialResolver, repository),
+		command.NewBranch(ai.NewDefaultProviderFactory(), credentialResolver, repository),
+		command.NewSplit(ai.NewDefaultProviderFactory(), credentialResolver, repository),
 	}
-	app := cli.New(commandsToRegister)
-	output, err := app.Run(args)
+	app := cli.New(commandsToRegister, func() (*vo.Configuration, error) {
+		return loadConfiguration(configurationFilePath, globalOptions.Profile)
+	})
+	result, err := app.Run(args)
 	if err != nil {
-		exitWithMessage(
-			vo.ExitCodeError,
-			vo.NewMarkupText(fmt.Sprintf("<error>%s</error>", err.Error())),
-		)
+		exitWithError(vo.ExitCodeError, err, globalOptions)
 	}
-	exitWithMessage(output.ExitCode, output.Message)
+	exitWithResult(result, globalOptions)
 }
 
-func getConfigurationFilePath() (string, error) {
-	homeDirPath, err := os.UserHomeDir()
+func getConfigurationFilePath(configOption string) (string, error) {
+	resolveConfigurationFilePath := usecase.NewResolveConfigurationFilePath()
+	output, err := resolveConfigurationFilePath.Execute(&usecase.ResolveConfigurationFilePathInput{
+		ConfigOption: configOption,
+		LookupEnv:    os.LookupEnv,
+	})
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(homeDirPath, ".config"), nil
+	return output.ConfigurationFilePath, nil
 }
 
-func exitWithMessage(exitCode vo.ExitCode, message *vo.MarkupText) {
+func exitWithError(exitCode vo.ExitCode, err error, globalOptions *cli.GlobalOptions) {
+	exitWithResult(&dispatcher.Result{
+		ExitCode: exitCode,
+		Message:  vo.NewMarkupText(fmt.Sprintf("<error>%s</error>", err.Error())),
+	}, globalOptions)
+}
+
+// exitWithResult prints the result to stdout on success and to stderr
+// otherwise, as a JSON document with --output=json. Colors are only used
+// when the stream written to supports them.
+func exitWithResult(result *dispatcher.Result, globalOptions *cli.GlobalOptions) {
 	outputChannel := os.Stdout
-	if exitCode != vo.ExitCodeSuccess {
+	if result.ExitCode != vo.ExitCodeSuccess {
 		outputChannel = os.Stderr
 	}
-	_, _ = fmt.Fprintln(outputChannel, message.ToANSI())
-	os.Exit(int(exitCode))
+	text := result.Message.StripMarkup()
+	if cli.UseColor(globalOptions.Color, outputChannel, os.LookupEnv) {
+		text = result.Message.ToANSI()
+	}
+	if globalOptions.Output == cli.OutputJSON {
+		var err error
+		text, err = result.ToJSON()
+		if err != nil {
+			_, _ = fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(int(vo.ExitCodeError))
+		}
+	}
+	_, _ = fmt.Fprintln(outputChannel, text)
+	os.Exit(int(result.ExitCode))
 }
 
-func loadConfiguration(configurationDirPath string) (*vo.Configuration, error) {
-	configurationFilePath := filepath.Join(configurationDirPath, "commit.json")
-	data, err := os.ReadFile(configurationFilePath)
+func loadConfiguration(configurationFilePath string, profile string) (*vo.Configuration, error) {
+	workingDirPath, err := os.Getwd()
 	if err != nil {
 		return nil, err
 	}
-	var configuration vo.Configuration
-	err = json.Unmarshal(data, &configuration)
+	loadConfiguration := usecase.NewLoadConfiguration()
+	output, err := loadConfiguration.Execute(&usecase.LoadConfigurationInput{
+		ConfigurationFilePath: configurationFilePath,
+		WorkingDirPath:        workingDirPath,
+		Profile:               profile,
+		LookupEnv:             os.LookupEnv,
+	})
 	if err != nil {
 		return nil, err
 	}
-	return &configuration, nil
+	return output.Configuration, nil
 }
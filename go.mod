@@ -1,3 +1,11 @@
 module github.com/yusadeol/go-commit
 
 go 1.24.2
+
+require (
+	github.com/BurntSushi/toml v1.6.0
+	golang.org/x/term v0.35.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require golang.org/x/sys v0.36.0 // indirect
@@ -2,27 +2,29 @@ package cli
 
 import (
 	"github.com/yusadeol/go-commit/internal/adapter/cli/dispatcher"
-	"github.com/yusadeol/go-commit/internal/domain/vo"
 )
 
 type CLI struct {
 	commandDispatcher *dispatcher.CommandDispatcher
 }
 
-func New(commandsToRegister []dispatcher.Command) *CLI {
+func New(commandsToRegister []dispatcher.Command, configurationLoader dispatcher.ConfigurationLoader) *CLI {
 	commandDispatcher := dispatcher.NewCommandDispatcher()
+	commandDispatcher.SetConfigurationLoader(configurationLoader)
+	commandDispatcher.SetGlobalOptions(globalOptions)
 	for _, commandToRegister := range commandsToRegister {
 		commandDispatcher.Register(commandToRegister)
 	}
+	commandDispatcher.RegisterCompletionCommands()
+	commandDispatcher.RegisterDocsCommand()
 	return &CLI{commandDispatcher: commandDispatcher}
 }
 
 func (a CLI) Run(args []string) (*dispatcher.Result, error) {
-	if len(args) == 0 {
-		return &dispatcher.Result{
-			ExitCode: vo.ExitCodeError,
-			Message:  vo.NewMarkupText("<error>No command provided.</error>"),
-		}, nil
+	if len(args) == 0 || args[0] == "--help" || args[0] == "-h" {
+		result := dispatcher.NewResult()
+		result.Message = a.commandDispatcher.Usage()
+		return result, nil
 	}
 	return a.commandDispatcher.Dispatch(args[0], args[1:])
 }
@@ -0,0 +1,35 @@
+package cli
+
+import (
+	"os"
+
+	"golang.org/x/term"
+)
+
+const (
+	ColorAuto   = "auto"
+	ColorAlways = "always"
+	ColorNever  = "never"
+)
+
+// UseColor reports whether markup written to file is rendered as ANSI colors.
+// With auto, a non-empty NO_COLOR disables them and CLICOLOR_FORCE enables
+// them, otherwise they are used on terminals other than TERM=dumb.
+func UseColor(color string, file *os.File, lookupEnv func(key string) (string, bool)) bool {
+	switch color {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+	if value, exists := lookupEnv("NO_COLOR"); exists && value != "" {
+		return false
+	}
+	if value, exists := lookupEnv("CLICOLOR_FORCE"); exists && value != "" && value != "0" {
+		return true
+	}
+	if value, _ := lookupEnv("TERM"); value == "dumb" {
+		return false
+	}
+	return term.IsTerminal(int(file.Fd()))
+}
@@ -0,0 +1,42 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestUseColor(t *testing.T) {
+	file, err := os.Create(filepath.Join(t.TempDir(), "output.log"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	tests := []struct {
+		name     string
+		color    string
+		env      map[string]string
+		expected bool
+	}{
+		{name: "always", color: ColorAlways, env: map[string]string{"NO_COLOR": "1"}, expected: true},
+		{name: "never", color: ColorNever, env: map[string]string{"CLICOLOR_FORCE": "1"}, expected: false},
+		{name: "auto without a terminal", color: ColorAuto, expected: false},
+		{name: "auto forced", color: ColorAuto, env: map[string]string{"CLICOLOR_FORCE": "1", "TERM": "dumb"}, expected: true},
+		{name: "auto forced with zero", color: ColorAuto, env: map[string]string{"CLICOLOR_FORCE": "0"}, expected: false},
+		{name: "auto forced with NO_COLOR", color: ColorAuto, env: map[string]string{"CLICOLOR_FORCE": "1", "NO_COLOR": "1"}, expected: false},
+		{name: "auto forced with empty NO_COLOR", color: ColorAuto, env: map[string]string{"CLICOLOR_FORCE": "1", "NO_COLOR": ""}, expected: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			lookupEnv := func(key string) (string, bool) {
+				value, exists := test.env[key]
+				return value, exists
+			}
+			if got := UseColor(test.color, file, lookupEnv); got != test.expected {
+				t.Errorf("expected %v, got: %v", test.expected, got)
+			}
+		})
+	}
+}
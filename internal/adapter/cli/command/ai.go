@@ -0,0 +1,83 @@
+package command
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/yusadeol/go-commit/internal/adapter/cli/dispatcher"
+
+	"github.com/yusadeol/go-commit/internal/domain/vo"
+	"github.com/yusadeol/go-commit/internal/infra/service/credential"
+)
+
+func getAIOptions(configuration *vo.Configuration) []dispatcher.Option {
+	return []dispatcher.Option{getProviderOption(configuration), getModelOption(configuration), getLanguageOption(configuration)}
+}
+
+func getProviderOption(configuration *vo.Configuration) dispatcher.Option {
+	return dispatcher.Option{
+		Name:          "provider",
+		Flag:          "p",
+		Description:   "AI Provider",
+		AllowedValues: []string{"openai"},
+		Default:       configuration.DefaultAIProvider,
+	}
+}
+
+// getModelOption accepts any model, as providers release new ones often, but
+// completes the models listed in the configuration.
+func getModelOption(configuration *vo.Configuration) dispatcher.Option {
+	var modelCompletions []string
+	for _, aiProvider := range configuration.AIProviders {
+		modelCompletions = append(modelCompletions, aiProvider.Models...)
+	}
+	return dispatcher.Option{
+		Name:        "model",
+		Flag:        "m",
+		Description: "AI model, overriding the provider default model",
+		Completions: modelCompletions,
+		Default:     configuration.Model,
+	}
+}
+
+func getLanguageOption(configuration *vo.Configuration) dispatcher.Option {
+	return dispatcher.Option{
+		Name:          "language",
+		Flag:          "l",
+		Description:   "Language",
+		AllowedValues: slices.Sorted(maps.Keys(configuration.Languages)),
+		Default:       configuration.DefaultLanguage,
+	}
+}
+
+func getAIConfiguration(configuration *vo.Configuration, credentialResolver credential.Resolver, input *dispatcher.CommandInput) (*vo.AIProvider, *vo.Language, error) {
+	configurationAIProvider, err := getAIProviderConfiguration(configuration, credentialResolver, input)
+	if err != nil {
+		return nil, nil, err
+	}
+	configurationLanguage, configurationLanguageExists := configuration.Languages[input.Options["language"].Value]
+	if !configurationLanguageExists {
+		return nil, nil, fmt.Errorf("language %q configuration not found", input.Options["language"].Value)
+	}
+	return configurationAIProvider, &configurationLanguage, nil
+}
+
+// getAIProviderConfiguration returns a copy of the selected provider with its
+// model override applied and its API key resolved, so credentials are only
+// looked up when a command needs them.
+func getAIProviderConfiguration(configuration *vo.Configuration, credentialResolver credential.Resolver, input *dispatcher.CommandInput) (*vo.AIProvider, error) {
+	configurationAIProvider, configurationAIProviderExists := configuration.AIProviders[input.Options["provider"].Value]
+	if !configurationAIProviderExists {
+		return nil, fmt.Errorf("AI provider %q configuration not found", input.Options["provider"].Value)
+	}
+	apiKey, err := credentialResolver.Resolve(&configurationAIProvider)
+	if err != nil {
+		return nil, err
+	}
+	configurationAIProvider.APIKey = apiKey
+	if input.Options["model"].Value != "" {
+		configurationAIProvider.DefaultModel = input.Options["model"].Value
+	}
+	return &configurationAIProvider, nil
+}
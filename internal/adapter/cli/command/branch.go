@@ -0,0 +1,126 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/yusadeol/go-commit/internal/adapter/cli/dispatcher"
+
+	"github.com/yusadeol/go-commit/internal/app/usecase"
+	"github.com/yusadeol/go-commit/internal/domain/vo"
+	"github.com/yusadeol/go-commit/internal/infra/service/ai"
+	"github.com/yusadeol/go-commit/internal/infra/service/credential"
+	"github.com/yusadeol/go-commit/internal/infra/service/git"
+)
+
+// BranchPayload is the structured output of branch.
+type BranchPayload struct {
+	Name     string `json:"name"`
+	Switched bool   `json:"switched"`
+}
+
+type Branch struct {
+	configuration            *vo.Configuration
+	aiDefaultProviderFactory ai.ProviderFactory
+	credentialResolver       credential.Resolver
+	git                      *git.Git
+}
+
+func NewBranch(
+	aiDefaultProviderFactory ai.ProviderFactory,
+	credentialResolver credential.Resolver,
+	git *git.Git,
+) *Branch {
+	return &Branch{
+		configuration:            &vo.Configuration{},
+		aiDefaultProviderFactory: aiDefaultProviderFactory,
+		credentialResolver:       credentialResolver,
+		git:                      git,
+	}
+}
+
+func (b *Branch) SetConfiguration(configuration *vo.Configuration) {
+	b.configuration = configuration
+}
+
+func (b *Branch) GetName() string {
+	return "branch"
+}
+
+func (b *Branch) GetDescription() string {
+	return "Generate a branch name from a description"
+}
+
+func (b *Branch) GetArguments() []dispatcher.Argument {
+	return []dispatcher.Argument{
+		{Name: "description", Description: "Task description", Required: false},
+	}
+}
+
+func (b *Branch) GetOptions() []dispatcher.Option {
+	return []dispatcher.Option{
+		getProviderOption(b.configuration),
+		getModelOption(b.configuration),
+		{
+			Name:        "ticket",
+			Flag:        "t",
+			Description: "Ticket reference, such as PROJ-1234",
+		},
+		{
+			Name:        "switch",
+			Flag:        "s",
+			Description: "Switch to the new branch",
+			Type:        dispatcher.OptionTypeBool,
+			Default:     "false",
+		},
+	}
+}
+
+func (b *Branch) Execute(input *dispatcher.CommandInput) (*dispatcher.Result, error) {
+	result := dispatcher.NewResult()
+	configurationAIProvider, err := getAIProviderConfiguration(b.configuration, b.credentialResolver, input)
+	if err != nil {
+		return nil, err
+	}
+	description := input.Arguments["description"].Value
+	diff := ""
+	if description == "" {
+		diff, err = b.git.Diff("--staged")
+		if err != nil {
+			return nil, err
+		}
+		if diff == "" {
+			return nil, errors.New("no description or staged changes found")
+		}
+	}
+	generateBranchName := usecase.NewGenerateBranchName()
+	output, err := generateBranchName.Execute(&usecase.GenerateBranchNameInput{
+		AIDefaultProviderFactory: b.aiDefaultProviderFactory,
+		AIProvider:               configurationAIProvider,
+		Description:              description,
+		Diff:                     diff,
+		Ticket:                   input.Options["ticket"].Value,
+		TicketConfiguration:      &b.configuration.Ticket,
+		Pattern:                  b.configuration.BranchPattern,
+	})
+	if err != nil {
+		return nil, err
+	}
+	branchName, err := b.git.CheckBranchName(output.BranchName)
+	if err != nil {
+		return nil, err
+	}
+	payload := &BranchPayload{Name: branchName}
+	result.Payload = payload
+	if !input.Options["switch"].Bool() {
+		result.Message = vo.NewMarkupText(branchName)
+		return result, nil
+	}
+	err = b.git.SwitchCreate(branchName)
+	if err != nil {
+		return nil, err
+	}
+	payload.Switched = true
+	result.Message = vo.NewMarkupText(fmt.Sprintf("<success>switched to a new branch %s</success>", branchName))
+	return result, nil
+}
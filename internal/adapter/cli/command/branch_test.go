@@ -0,0 +1,93 @@
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/yusadeol/go-commit/internal/adapter/cli/dispatcher"
+
+	"github.com/yusadeol/go-commit/internal/domain/vo"
+)
+
+func TestBranch(t *testing.T) {
+	mockConfiguration := vo.Configuration{
+		AIProviders: map[string]vo.AIProvider{
+			"mock": {ID: "mock", APIKey: "fake-api-key", DefaultModel: "mock-model"},
+		},
+		BranchPattern: "<type>/<ticket>-<slug>",
+		Ticket:        vo.Ticket{Pattern: `[A-Z][A-Z0-9]+-[0-9]+`},
+	}
+
+	t.Run("should be able to generate a branch name from a description", func(t *testing.T) {
+		_, repository := newTestRepository(t)
+		branch := NewBranch(&MockDefaultProviderFactory{}, newTestCredentialResolver(t), repository)
+		branch.SetConfiguration(&mockConfiguration)
+		result, err := branch.Execute(&dispatcher.CommandInput{
+			Arguments: map[string]dispatcher.ArgumentInput{
+				"description": {Value: "PROJ-1234 rename the greeting function"},
+			},
+			Options: map[string]dispatcher.OptionInput{
+				"provider": {Value: "mock"},
+				"switch":   {Value: "false"},
+			},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := "feat/PROJ-1234-rename-function-and-update-greeting-message"
+		if result.Message.StripMarkup() != expected {
+			t.Fatalf("expected branch name %q, got: %q", expected, result.Message.StripMarkup())
+		}
+	})
+
+	t.Run("should extract the ticket with the configured pattern", func(t *testing.T) {
+		_, repository := newTestRepository(t)
+		branch := NewBranch(&MockDefaultProviderFactory{}, newTestCredentialResolver(t), repository)
+		configuration := mockConfiguration
+		configuration.Ticket = vo.Ticket{Pattern: `issue-([0-9]+)`}
+		branch.SetConfiguration(&configuration)
+		result, err := branch.Execute(&dispatcher.CommandInput{
+			Arguments: map[string]dispatcher.ArgumentInput{
+				"description": {Value: "PROJ-1234 fix issue-42 in the greeting function"},
+			},
+			Options: map[string]dispatcher.OptionInput{
+				"provider": {Value: "mock"},
+				"switch":   {Value: "false"},
+			},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := "feat/42-rename-function-and-update-greeting-message"
+		if result.Message.StripMarkup() != expected {
+			t.Fatalf("expected branch name %q, got: %q", expected, result.Message.StripMarkup())
+		}
+	})
+
+	t.Run("should be able to switch to the generated branch", func(t *testing.T) {
+		repositoryDirPath, repository := newTestRepository(t)
+		commitTestFile(t, repositoryDirPath, repository, "a.txt", "chore: initial commit")
+		branch := NewBranch(&MockDefaultProviderFactory{}, newTestCredentialResolver(t), repository)
+		branch.SetConfiguration(&mockConfiguration)
+		_, err := branch.Execute(&dispatcher.CommandInput{
+			Arguments: map[string]dispatcher.ArgumentInput{
+				"description": {Value: "rename the greeting function"},
+			},
+			Options: map[string]dispatcher.OptionInput{
+				"provider": {Value: "mock"},
+				"switch":   {Value: "true"},
+			},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		currentBranch, err := repository.Run("rev-parse", "--abbrev-ref", "HEAD")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := "feat/rename-function-and-update-greeting-message"
+		if strings.TrimSpace(currentBranch) != expected {
+			t.Fatalf("expected current branch %q, got: %q", expected, currentBranch)
+		}
+	})
+}
@@ -0,0 +1,90 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/yusadeol/go-commit/internal/adapter/cli/dispatcher"
+
+	"github.com/yusadeol/go-commit/internal/app/usecase"
+	"github.com/yusadeol/go-commit/internal/domain/vo"
+	"github.com/yusadeol/go-commit/internal/infra/service/git"
+)
+
+// BumpPayload is the structured output of bump.
+type BumpPayload struct {
+	CurrentVersion string `json:"current_version"`
+	NextVersion    string `json:"next_version"`
+	Bump           string `json:"bump"`
+	Tagged         bool   `json:"tagged"`
+}
+
+type Bump struct {
+	git *git.Git
+}
+
+func NewBump(git *git.Git) *Bump {
+	return &Bump{git: git}
+}
+
+func (b *Bump) GetName() string {
+	return "bump"
+}
+
+func (b *Bump) GetDescription() string {
+	return "Calculate the next semantic version from the commits since the last release"
+}
+
+func (b *Bump) GetArguments() []dispatcher.Argument {
+	return []dispatcher.Argument{}
+}
+
+func (b *Bump) GetOptions() []dispatcher.Option {
+	return []dispatcher.Option{
+		{
+			Name:        "pre-release",
+			Flag:        "r",
+			Description: "Pre-release channel, such as rc or beta",
+		},
+		{
+			Name:        "tag",
+			Flag:        "t",
+			Description: "Create an annotated tag",
+			Type:        dispatcher.OptionTypeBool,
+			Default:     "false",
+		},
+	}
+}
+
+func (b *Bump) Execute(input *dispatcher.CommandInput) (*dispatcher.Result, error) {
+	result := dispatcher.NewResult()
+	bumpVersion := usecase.NewBumpVersion()
+	output, err := bumpVersion.Execute(&usecase.BumpVersionInput{
+		Git:               b.git,
+		PreReleaseChannel: input.Options["pre-release"].Value,
+		CreateTag:         input.Options["tag"].Bool(),
+	})
+	if errors.Is(err, usecase.ErrNoReleasableChanges) {
+		result.ExitCode = vo.ExitCodeError
+		result.Message = vo.NewMarkupText("<info>no releasable changes since the last version</info>")
+		return result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	result.Payload = &BumpPayload{
+		CurrentVersion: output.CurrentVersion,
+		NextVersion:    output.NextVersion,
+		Bump:           output.Bump.String(),
+		Tagged:         output.Tagged,
+	}
+	message := []string{
+		fmt.Sprintf("<info>%s bump from %s</info>", output.Bump, output.CurrentVersion),
+		fmt.Sprintf("<comment>%s</comment>", output.NextVersion),
+	}
+	if output.Tagged {
+		message = append(message, fmt.Sprintf("<success>tag %s created successfully</success>", output.NextVersion))
+	}
+	result.Message = vo.NewColoredMultilineText(message)
+	return result, nil
+}
@@ -0,0 +1,105 @@
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/yusadeol/go-commit/internal/adapter/cli/dispatcher"
+
+	"github.com/yusadeol/go-commit/internal/domain/vo"
+)
+
+func TestBump(t *testing.T) {
+	t.Run("should be able to calculate the next version", func(t *testing.T) {
+		tests := []struct {
+			name       string
+			messages   []string
+			preRelease string
+			expected   string
+		}{
+			{name: "patch", messages: []string{"fix: handle nil pointer"}, expected: "v1.2.4"},
+			{name: "minor", messages: []string{"fix: handle nil pointer", "feat: add flag"}, expected: "v1.3.0"},
+			{name: "major with bang", messages: []string{"feat!: drop flag"}, expected: "v2.0.0"},
+			{name: "major with footer", messages: []string{"fix: rename flag\n\nBREAKING CHANGE: flag renamed"}, expected: "v2.0.0"},
+			{name: "pre-release", messages: []string{"feat: add flag"}, preRelease: "rc", expected: "v1.3.0-rc.1"},
+		}
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				repositoryDirPath, repository := newTestRepository(t)
+				commitTestFile(t, repositoryDirPath, repository, "initial.txt", "chore: initial commit")
+				_, err := repository.Run("tag", "v1.2.3")
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				for index, message := range test.messages {
+					commitTestFile(t, repositoryDirPath, repository, string(rune('a'+index))+".txt", message)
+				}
+				bump := NewBump(repository)
+				result, err := bump.Execute(&dispatcher.CommandInput{
+					Options: map[string]dispatcher.OptionInput{
+						"pre-release": {Value: test.preRelease},
+						"tag":         {Value: "false"},
+					},
+				})
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if !strings.Contains(result.Message.StripMarkup(), test.expected) {
+					t.Fatalf("expected message to contain %q, got: %q", test.expected, result.Message.StripMarkup())
+				}
+			})
+		}
+	})
+
+	t.Run("should be able to tag the next pre-release", func(t *testing.T) {
+		repositoryDirPath, repository := newTestRepository(t)
+		commitTestFile(t, repositoryDirPath, repository, "a.txt", "feat: add first feature")
+		_, err := repository.Run("tag", "v1.0.0")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		commitTestFile(t, repositoryDirPath, repository, "b.txt", "fix: handle empty input")
+		_, err = repository.Run("tag", "v1.0.1-rc.1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		commitTestFile(t, repositoryDirPath, repository, "c.txt", "fix: handle invalid input")
+		bump := NewBump(repository)
+		result, err := bump.Execute(&dispatcher.CommandInput{
+			Options: map[string]dispatcher.OptionInput{
+				"pre-release": {Value: "rc"},
+				"tag":         {Value: "true"},
+			},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.ExitCode != vo.ExitCodeSuccess {
+			t.Fatalf("unexpected exit code: %v", result.ExitCode)
+		}
+		tags, err := repository.Tags("HEAD")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.Contains(strings.Join(tags, " "), "v1.0.1-rc.2") {
+			t.Fatalf("expected tag v1.0.1-rc.2 to be created, got: %v", tags)
+		}
+	})
+
+	t.Run("should return error when there are no releasable changes", func(t *testing.T) {
+		repositoryDirPath, repository := newTestRepository(t)
+		commitTestFile(t, repositoryDirPath, repository, "a.txt", "docs: update readme")
+		bump := NewBump(repository)
+		result, err := bump.Execute(&dispatcher.CommandInput{
+			Options: map[string]dispatcher.OptionInput{
+				"tag": {Value: "false"},
+			},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.ExitCode != vo.ExitCodeError {
+			t.Fatalf("expected ExitCodeError, got: %v", result.ExitCode)
+		}
+	})
+}
@@ -0,0 +1,153 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/yusadeol/go-commit/internal/adapter/cli/dispatcher"
+
+	"github.com/yusadeol/go-commit/internal/app/usecase"
+	"github.com/yusadeol/go-commit/internal/domain/vo"
+	"github.com/yusadeol/go-commit/internal/infra/service/ai"
+	"github.com/yusadeol/go-commit/internal/infra/service/credential"
+	"github.com/yusadeol/go-commit/internal/infra/service/git"
+)
+
+// ChangelogPayload is the structured output of changelog. Release is the
+// rendered section of the version, empty when no entries were found and the
+// file was left unchanged.
+type ChangelogPayload struct {
+	Version string `json:"version"`
+	File    string `json:"file"`
+	Release string `json:"release"`
+	Updated bool   `json:"updated"`
+}
+
+type Changelog struct {
+	configuration            *vo.Configuration
+	aiDefaultProviderFactory ai.ProviderFactory
+	credentialResolver       credential.Resolver
+	git                      *git.Git
+}
+
+func NewChangelog(
+	aiDefaultProviderFactory ai.ProviderFactory,
+	credentialResolver credential.Resolver,
+	git *git.Git,
+) *Changelog {
+	return &Changelog{
+		configuration:            &vo.Configuration{},
+		aiDefaultProviderFactory: aiDefaultProviderFactory,
+		credentialResolver:       credentialResolver,
+		git:                      git,
+	}
+}
+
+func (c *Changelog) SetConfiguration(configuration *vo.Configuration) {
+	c.configuration = configuration
+}
+
+// RequiresConfiguration is only true with --polish, so a plain changelog is
+// generated without a configuration file.
+func (c *Changelog) RequiresConfiguration(input *dispatcher.CommandInput) bool {
+	return input.Options["polish"].Bool()
+}
+
+func (c *Changelog) GetName() string {
+	return "changelog"
+}
+
+func (c *Changelog) GetDescription() string {
+	return "Update the changelog with the Conventional Commits since the last tag"
+}
+
+func (c *Changelog) GetArguments() []dispatcher.Argument {
+	return []dispatcher.Argument{}
+}
+
+func (c *Changelog) GetOptions() []dispatcher.Option {
+	return append(getAIOptions(c.configuration),
+		dispatcher.Option{
+			Name:        "from",
+			Flag:        "f",
+			Description: "Tag or ref to start from (defaults to the previous tag)",
+		},
+		dispatcher.Option{
+			Name:        "to",
+			Flag:        "t",
+			Description: "Ref to end at",
+			Default:     "HEAD",
+		},
+		dispatcher.Option{
+			Name:        "file",
+			Description: "Changelog file",
+			Default:     "CHANGELOG.md",
+		},
+		dispatcher.Option{
+			Name:        "polish",
+			Description: "Polish the entries with AI",
+			Type:        dispatcher.OptionTypeBool,
+			Default:     "false",
+		},
+	)
+}
+
+func (c *Changelog) Execute(input *dispatcher.CommandInput) (*dispatcher.Result, error) {
+	result := dispatcher.NewResult()
+	to := input.Options["to"].Value
+	from := input.Options["from"].Value
+	if from == "" {
+		from = c.git.LatestTag(to + "^")
+	}
+	revisionRange := to
+	if from != "" {
+		revisionRange = from + ".." + to
+	}
+	commits, err := c.git.Log(revisionRange)
+	if err != nil {
+		return nil, err
+	}
+	version := c.git.ExactTag(to)
+	if version == "" {
+		version = "Unreleased"
+	}
+	date, err := c.git.CommitDate(to)
+	if err != nil {
+		return nil, err
+	}
+	generateChangelogInput := &usecase.GenerateChangelogInput{
+		Commits:           commits,
+		Version:           version,
+		Date:              date,
+		ChangelogFilePath: input.Options["file"].Value,
+	}
+	if input.Options["polish"].Bool() {
+		configurationAIProvider, configurationLanguage, err := getAIConfiguration(c.configuration, c.credentialResolver, input)
+		if err != nil {
+			return nil, err
+		}
+		generateChangelogInput.AIDefaultProviderFactory = c.aiDefaultProviderFactory
+		generateChangelogInput.AIProvider = configurationAIProvider
+		generateChangelogInput.Language = configurationLanguage
+	}
+	generateChangelog := usecase.NewGenerateChangelog()
+	output, err := generateChangelog.Execute(generateChangelogInput)
+	payload := &ChangelogPayload{Version: version, File: input.Options["file"].Value}
+	if errors.Is(err, usecase.ErrNoChangelogEntries) {
+		result.Payload = payload
+		result.Message = vo.NewMarkupText(fmt.Sprintf("<info>no conventional commits found in %s</info>", revisionRange))
+		return result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	message := []string{
+		fmt.Sprintf("<info>%s updated successfully!</info>", input.Options["file"].Value),
+		fmt.Sprintf("<comment>%s</comment>", output.Release),
+	}
+	result.Message = vo.NewColoredMultilineText(message)
+	payload.Release = output.Release
+	payload.Updated = true
+	result.Payload = payload
+	return result, nil
+}
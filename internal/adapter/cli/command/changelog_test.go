@@ -0,0 +1,109 @@
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/yusadeol/go-commit/internal/adapter/cli/dispatcher"
+
+	"github.com/yusadeol/go-commit/internal/domain/vo"
+	"github.com/yusadeol/go-commit/internal/infra/service/git"
+)
+
+func newTestRepository(t *testing.T) (string, *git.Git) {
+	t.Helper()
+	repositoryDirPath := t.TempDir()
+	repository := git.New(repositoryDirPath)
+	for _, args := range [][]string{
+		{"init", "--initial-branch=main"},
+		{"config", "user.name", "Test"},
+		{"config", "user.email", "test@example.com"},
+		{"config", "commit.gpgsign", "false"},
+		{"config", "tag.gpgsign", "false"},
+	} {
+		_, err := repository.Run(args...)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	return repositoryDirPath, repository
+}
+
+func commitTestFile(t *testing.T, repositoryDirPath string, repository *git.Git, fileName string, message string) {
+	t.Helper()
+	err := os.WriteFile(filepath.Join(repositoryDirPath, fileName), []byte(message), 0644)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err = repository.Run("add", fileName)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err = repository.Run("commit", "-m", message)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestChangelog(t *testing.T) {
+	t.Run("should be able to prepend the changes since the last tag", func(t *testing.T) {
+		repositoryDirPath, repository := newTestRepository(t)
+		commitTestFile(t, repositoryDirPath, repository, "a.txt", "feat: add first feature")
+		_, err := repository.Run("tag", "v1.0.0")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		commitTestFile(t, repositoryDirPath, repository, "b.txt", "fix(api): handle empty responses")
+		commitTestFile(t, repositoryDirPath, repository, "c.txt", "feat(cli)!: drop the legacy flags\n\nBREAKING CHANGE: the --old flag was removed")
+		commitTestFile(t, repositoryDirPath, repository, "d.txt", "update readme")
+		changelogFilePath := filepath.Join(repositoryDirPath, "CHANGELOG.md")
+		err = os.WriteFile(changelogFilePath, []byte("# Changelog\n\n## [v1.0.0] - 2025-01-01\n\n### Added\n\n- add first feature\n"), 0644)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		changelog := NewChangelog(&MockDefaultProviderFactory{}, newTestCredentialResolver(t), repository)
+		result, err := changelog.Execute(&dispatcher.CommandInput{
+			Options: map[string]dispatcher.OptionInput{
+				"to":     {Value: "HEAD"},
+				"file":   {Value: changelogFilePath},
+				"polish": {Value: "false"},
+			},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.ExitCode != vo.ExitCodeSuccess {
+			t.Fatalf("unexpected exit code: %v", result.ExitCode)
+		}
+		data, err := os.ReadFile(changelogFilePath)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		content := string(data)
+		for _, expected := range []string{
+			"## [Unreleased]",
+			"### BREAKING CHANGES\n\n- **cli:** the --old flag was removed",
+			"### Added\n\n- **cli:** **BREAKING** drop the legacy flags",
+			"### Fixed\n\n- **api:** handle empty responses",
+		} {
+			if !strings.Contains(content, expected) {
+				t.Fatalf("expected changelog to contain %q, got: %q", expected, content)
+			}
+		}
+		if strings.Index(content, "## [Unreleased]") > strings.Index(content, "## [v1.0.0]") {
+			t.Fatalf("expected the new release to be prepended, got: %q", content)
+		}
+		if strings.Contains(content, "update readme") {
+			t.Fatalf("expected non conventional commits to be skipped, got: %q", content)
+		}
+		payload, isChangelogPayload := result.Payload.(*ChangelogPayload)
+		if !isChangelogPayload || !payload.Updated || payload.Version != "Unreleased" || payload.File != changelogFilePath {
+			t.Fatalf("expected an updated changelog payload, got: %+v", result.Payload)
+		}
+		if !strings.Contains(content, payload.Release) || !strings.Contains(payload.Release, "handle empty responses") {
+			t.Fatalf("expected the payload to hold the rendered release, got: %q", payload.Release)
+		}
+	})
+}
@@ -0,0 +1,361 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/yusadeol/go-commit/internal/adapter/cli/dispatcher"
+
+	"github.com/yusadeol/go-commit/internal/app/usecase"
+	"github.com/yusadeol/go-commit/internal/domain/vo"
+)
+
+// NewConfig groups the subcommands that read and change the configuration
+// file, such as config get and config set.
+func NewConfig(configurationFilePath string) *dispatcher.CommandGroup {
+	configSubcommand := configSubcommand{configurationFilePath: configurationFilePath}
+	return dispatcher.NewCommandGroup(
+		"config",
+		"Get, set and validate configuration values",
+		&ConfigGet{configSubcommand},
+		&ConfigSet{configSubcommand},
+		&ConfigUnset{configSubcommand},
+		&ConfigList{configSubcommand},
+		&ConfigEdit{configSubcommand},
+		&ConfigValidate{configSubcommand},
+		&ConfigMigrate{configSubcommand},
+		&ConfigPath{configSubcommand},
+	)
+}
+
+// ConfigValuePayload is the structured output of config get, set and unset.
+// Value is null once a key is unset.
+type ConfigValuePayload struct {
+	Key   string `json:"key"`
+	Value any    `json:"value"`
+}
+
+// ConfigListPayload is the structured output of config list, with API keys
+// masked as in the text output.
+type ConfigListPayload struct {
+	Values map[string]any `json:"values"`
+}
+
+// ConfigValidatePayload is the structured output of config validate and
+// config edit.
+type ConfigValidatePayload struct {
+	File   string   `json:"file"`
+	Valid  bool     `json:"valid"`
+	Errors []string `json:"errors"`
+}
+
+// ConfigMigratePayload is the structured output of config migrate. BackupFile
+// is empty when the file already used the current schema version.
+type ConfigMigratePayload struct {
+	FromSchemaVersion int    `json:"from_schema_version"`
+	ToSchemaVersion   int    `json:"to_schema_version"`
+	BackupFile        string `json:"backup_file"`
+}
+
+// ConfigPathPayload is the structured output of config path.
+type ConfigPathPayload struct {
+	File string `json:"file"`
+}
+
+var configKeyArgument = dispatcher.Argument{
+	Name:        "key",
+	Description: "Dotted key, such as ai_providers.openai.default_model",
+	Required:    true,
+}
+
+type configSubcommand struct {
+	configurationFilePath string
+}
+
+func (c *configSubcommand) GetArguments() []dispatcher.Argument {
+	return []dispatcher.Argument{}
+}
+
+func (c *configSubcommand) GetOptions() []dispatcher.Option {
+	return []dispatcher.Option{}
+}
+
+// keyNotFound turns a missing key into an error result, leaving other errors
+// to the caller.
+func (c *configSubcommand) keyNotFound(result *dispatcher.Result, err error) (*dispatcher.Result, error) {
+	if errors.Is(err, usecase.ErrConfigurationKeyNotFound) {
+		result.ExitCode = vo.ExitCodeError
+		result.Message = vo.NewMarkupText(fmt.Sprintf("<error>%s</error>", err.Error()))
+		return result, nil
+	}
+	return nil, err
+}
+
+func (c *configSubcommand) validate(result *dispatcher.Result) {
+	validateConfigurationFile := usecase.NewValidateConfigurationFile()
+	err := validateConfigurationFile.Execute(&usecase.ValidateConfigurationFileInput{
+		ConfigurationFilePath: c.configurationFilePath,
+	})
+	payload := &ConfigValidatePayload{File: c.configurationFilePath, Valid: err == nil, Errors: []string{}}
+	result.Payload = payload
+	if err == nil {
+		result.Message = vo.NewMarkupText("<success>configuration is valid</success>")
+		return
+	}
+	lines := []string{fmt.Sprintf("<error>%s is invalid:</error>", c.configurationFilePath)}
+	for _, line := range strings.Split(err.Error(), "\n") {
+		lines = append(lines, fmt.Sprintf("<error>- %s</error>", line))
+		payload.Errors = append(payload.Errors, line)
+	}
+	result.ExitCode = vo.ExitCodeConfiguration
+	result.Message = vo.NewColoredMultilineText(lines)
+}
+
+type ConfigGet struct {
+	configSubcommand
+}
+
+func (c *ConfigGet) GetName() string {
+	return "get"
+}
+
+func (c *ConfigGet) GetDescription() string {
+	return "Print a configuration value"
+}
+
+func (c *ConfigGet) GetArguments() []dispatcher.Argument {
+	return []dispatcher.Argument{configKeyArgument}
+}
+
+func (c *ConfigGet) Execute(input *dispatcher.CommandInput) (*dispatcher.Result, error) {
+	result := dispatcher.NewResult()
+	getConfigurationValue := usecase.NewGetConfigurationValue()
+	output, err := getConfigurationValue.Execute(&usecase.GetConfigurationValueInput{
+		ConfigurationFilePath: c.configurationFilePath,
+		Key:                   input.Arguments["key"].Value,
+	})
+	if err != nil {
+		return c.keyNotFound(result, err)
+	}
+	result.Message = vo.NewMarkupText(usecase.FormatConfigurationValue(output.Value))
+	result.Payload = &ConfigValuePayload{Key: input.Arguments["key"].Value, Value: output.Value}
+	return result, nil
+}
+
+type ConfigSet struct {
+	configSubcommand
+}
+
+func (c *ConfigSet) GetName() string {
+	return "set"
+}
+
+func (c *ConfigSet) GetDescription() string {
+	return "Set a configuration value, stored as JSON when it parses as JSON"
+}
+
+func (c *ConfigSet) GetArguments() []dispatcher.Argument {
+	return []dispatcher.Argument{
+		configKeyArgument,
+		{Name: "value", Description: "Value to set", Required: true},
+	}
+}
+
+func (c *ConfigSet) Execute(input *dispatcher.CommandInput) (*dispatcher.Result, error) {
+	result := dispatcher.NewResult()
+	key := input.Arguments["key"].Value
+	setConfigurationValue := usecase.NewSetConfigurationValue()
+	output, err := setConfigurationValue.Execute(&usecase.SetConfigurationValueInput{
+		ConfigurationFilePath: c.configurationFilePath,
+		Key:                   key,
+		Value:                 input.Arguments["value"].Value,
+	})
+	if err != nil {
+		return c.keyNotFound(result, err)
+	}
+	result.Message = vo.NewMarkupText(fmt.Sprintf("<success>%s updated successfully</success>", key))
+	result.Payload = &ConfigValuePayload{Key: key, Value: output.Value}
+	return result, nil
+}
+
+type ConfigUnset struct {
+	configSubcommand
+}
+
+func (c *ConfigUnset) GetName() string {
+	return "unset"
+}
+
+func (c *ConfigUnset) GetDescription() string {
+	return "Remove a configuration value, so its default is used"
+}
+
+func (c *ConfigUnset) GetArguments() []dispatcher.Argument {
+	return []dispatcher.Argument{configKeyArgument}
+}
+
+func (c *ConfigUnset) Execute(input *dispatcher.CommandInput) (*dispatcher.Result, error) {
+	result := dispatcher.NewResult()
+	key := input.Arguments["key"].Value
+	unsetConfigurationValue := usecase.NewUnsetConfigurationValue()
+	err := unsetConfigurationValue.Execute(&usecase.UnsetConfigurationValueInput{
+		ConfigurationFilePath: c.configurationFilePath,
+		Key:                   key,
+	})
+	if err != nil {
+		return c.keyNotFound(result, err)
+	}
+	result.Message = vo.NewMarkupText(fmt.Sprintf("<success>%s removed successfully</success>", key))
+	result.Payload = &ConfigValuePayload{Key: key}
+	return result, nil
+}
+
+type ConfigList struct {
+	configSubcommand
+}
+
+func (c *ConfigList) GetName() string {
+	return "list"
+}
+
+func (c *ConfigList) GetDescription() string {
+	return "List every configuration value, with API keys masked"
+}
+
+func (c *ConfigList) Execute(_ *dispatcher.CommandInput) (*dispatcher.Result, error) {
+	result := dispatcher.NewResult()
+	listConfigurationValues := usecase.NewListConfigurationValues()
+	output, err := listConfigurationValues.Execute(&usecase.ListConfigurationValuesInput{
+		ConfigurationFilePath: c.configurationFilePath,
+	})
+	if err != nil {
+		return nil, err
+	}
+	lines := make([]string, 0, len(output.Keys))
+	payload := &ConfigListPayload{Values: make(map[string]any, len(output.Keys))}
+	for _, key := range output.Keys {
+		value := output.Values[key]
+		formattedValue := usecase.FormatConfigurationValue(value)
+		if strings.HasSuffix(key, "api_key") && formattedValue != "" {
+			value = "********"
+			formattedValue = "********"
+		}
+		lines = append(lines, fmt.Sprintf("<info>%s</info>=%s", key, formattedValue))
+		payload.Values[key] = value
+	}
+	result.Message = vo.NewColoredMultilineText(lines)
+	result.Payload = payload
+	return result, nil
+}
+
+type ConfigEdit struct {
+	configSubcommand
+}
+
+func (c *ConfigEdit) GetName() string {
+	return "edit"
+}
+
+func (c *ConfigEdit) GetDescription() string {
+	return "Open the configuration file in $VISUAL or $EDITOR and validate it"
+}
+
+// Execute opens the file in $VISUAL or $EDITOR, which may include arguments
+// such as "code --wait", and validates it once the editor exits.
+func (c *ConfigEdit) Execute(_ *dispatcher.CommandInput) (*dispatcher.Result, error) {
+	result := dispatcher.NewResult()
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	cmd := exec.Command("sh", "-c", editor+` "$1"`, "sh", c.configurationFilePath)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	err := cmd.Run()
+	if err != nil {
+		return nil, err
+	}
+	c.validate(result)
+	return result, nil
+}
+
+type ConfigValidate struct {
+	configSubcommand
+}
+
+func (c *ConfigValidate) GetName() string {
+	return "validate"
+}
+
+func (c *ConfigValidate) GetDescription() string {
+	return "Check the configuration file for unknown keys and invalid references"
+}
+
+func (c *ConfigValidate) Execute(_ *dispatcher.CommandInput) (*dispatcher.Result, error) {
+	result := dispatcher.NewResult()
+	c.validate(result)
+	return result, nil
+}
+
+type ConfigMigrate struct {
+	configSubcommand
+}
+
+func (c *ConfigMigrate) GetName() string {
+	return "migrate"
+}
+
+func (c *ConfigMigrate) GetDescription() string {
+	return "Upgrade the configuration file to the current schema version"
+}
+
+func (c *ConfigMigrate) Execute(_ *dispatcher.CommandInput) (*dispatcher.Result, error) {
+	result := dispatcher.NewResult()
+	migrateConfigurationFile := usecase.NewMigrateConfigurationFile()
+	output, err := migrateConfigurationFile.Execute(&usecase.MigrateConfigurationFileInput{
+		ConfigurationFilePath: c.configurationFilePath,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.Payload = &ConfigMigratePayload{
+		FromSchemaVersion: output.FromSchemaVersion,
+		ToSchemaVersion:   output.ToSchemaVersion,
+		BackupFile:        output.BackupFilePath,
+	}
+	if output.FromSchemaVersion == output.ToSchemaVersion {
+		result.Message = vo.NewMarkupText(fmt.Sprintf("<info>configuration already uses schema version %d</info>", output.ToSchemaVersion))
+		return result, nil
+	}
+	result.Message = vo.NewColoredMultilineText([]string{
+		fmt.Sprintf("<success>configuration migrated from schema version %d to %d</success>", output.FromSchemaVersion, output.ToSchemaVersion),
+		fmt.Sprintf("<info>the original was saved to %s</info>", output.BackupFilePath),
+	})
+	return result, nil
+}
+
+type ConfigPath struct {
+	configSubcommand
+}
+
+func (c *ConfigPath) GetName() string {
+	return "path"
+}
+
+func (c *ConfigPath) GetDescription() string {
+	return "Print the path of the configuration file"
+}
+
+func (c *ConfigPath) Execute(_ *dispatcher.CommandInput) (*dispatcher.Result, error) {
+	result := dispatcher.NewResult()
+	result.Message = vo.NewMarkupText(c.configurationFilePath)
+	result.Payload = &ConfigPathPayload{File: c.configurationFilePath}
+	return result, nil
+}
@@ -0,0 +1,406 @@
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/yusadeol/go-commit/internal/adapter/cli/dispatcher"
+
+	"github.com/yusadeol/go-commit/internal/domain/vo"
+)
+
+func dispatchConfig(config *dispatcher.CommandGroup, arguments ...string) (*dispatcher.Result, error) {
+	commandDispatcher := dispatcher.NewCommandDispatcher()
+	commandDispatcher.Register(config)
+	return commandDispatcher.Dispatch(config.GetName(), arguments)
+}
+
+func executeConfig(t *testing.T, config *dispatcher.CommandGroup, arguments ...string) *dispatcher.Result {
+	t.Helper()
+	result, err := dispatchConfig(config, arguments...)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return result
+}
+
+func newTestConfig(t *testing.T) (string, *dispatcher.CommandGroup) {
+	t.Helper()
+	configurationFilePath := filepath.Join(t.TempDir(), "commit.json")
+	_, err := newTestInit(t, configurationFilePath).Execute(&dispatcher.CommandInput{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return configurationFilePath, NewConfig(configurationFilePath)
+}
+
+func TestConfig(t *testing.T) {
+	t.Run("should be able to set and get a nested value", func(t *testing.T) {
+		_, config := newTestConfig(t)
+		result := executeConfig(t, config, "set", "ai_providers.openai.default_model", "gpt-4.1-mini")
+		if result.ExitCode != vo.ExitCodeSuccess {
+			t.Fatalf("unexpected exit code: %v, message: %q", result.ExitCode, result.Message.StripMarkup())
+		}
+		expectedPayload := ConfigValuePayload{Key: "ai_providers.openai.default_model", Value: "gpt-4.1-mini"}
+		if payload, isValuePayload := result.Payload.(*ConfigValuePayload); !isValuePayload || *payload != expectedPayload {
+			t.Fatalf("expected payload %+v, got: %+v", expectedPayload, result.Payload)
+		}
+		result = executeConfig(t, config, "get", "ai_providers.openai.default_model")
+		if result.Message.StripMarkup() != "gpt-4.1-mini" {
+			t.Fatalf("expected %q, got: %q", "gpt-4.1-mini", result.Message.StripMarkup())
+		}
+		if payload, isValuePayload := result.Payload.(*ConfigValuePayload); !isValuePayload || *payload != expectedPayload {
+			t.Fatalf("expected payload %+v, got: %+v", expectedPayload, result.Payload)
+		}
+	})
+
+	t.Run("should be able to set typed values", func(t *testing.T) {
+		_, config := newTestConfig(t)
+		executeConfig(t, config, "set", "history_examples.count", "3")
+		executeConfig(t, config, "set", "types", `["feat","fix"]`)
+		result := executeConfig(t, config, "get", "history_examples")
+		expected := `{"count":3,"enabled":true}`
+		if result.Message.StripMarkup() != expected {
+			t.Fatalf("expected %q, got: %q", expected, result.Message.StripMarkup())
+		}
+		result = executeConfig(t, config, "get", "types")
+		if result.Message.StripMarkup() != `["feat","fix"]` {
+			t.Fatalf("expected %q, got: %q", `["feat","fix"]`, result.Message.StripMarkup())
+		}
+	})
+
+	t.Run("should keep the file untouched when the value has the wrong type", func(t *testing.T) {
+		configurationFilePath, config := newTestConfig(t)
+		before, err := os.ReadFile(configurationFilePath)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		_, err = dispatchConfig(config, "set", "history_examples.count", "many")
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+		after, err := os.ReadFile(configurationFilePath)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(before) != string(after) {
+			t.Fatalf("expected the configuration file to be untouched")
+		}
+	})
+
+	t.Run("should reject null instead of removing the key", func(t *testing.T) {
+		_, config := newTestConfig(t)
+		_, err := dispatchConfig(config, "set", "branch_pattern", "null")
+		if err == nil || !strings.Contains(err.Error(), "unset") {
+			t.Fatalf("expected an error mentioning unset, got: %v", err)
+		}
+		result := executeConfig(t, config, "get", "branch_pattern")
+		if result.ExitCode != vo.ExitCodeSuccess {
+			t.Fatalf("expected the key to be kept, got exit code: %v", result.ExitCode)
+		}
+	})
+
+	t.Run("should be able to unset a value", func(t *testing.T) {
+		_, config := newTestConfig(t)
+		result := executeConfig(t, config, "unset", "branch_pattern")
+		if result.ExitCode != vo.ExitCodeSuccess {
+			t.Fatalf("unexpected exit code: %v", result.ExitCode)
+		}
+		if payload, isValuePayload := result.Payload.(*ConfigValuePayload); !isValuePayload || payload.Key != "branch_pattern" || payload.Value != nil {
+			t.Fatalf("expected a payload without value, got: %+v", result.Payload)
+		}
+		result = executeConfig(t, config, "get", "branch_pattern")
+		if result.ExitCode != vo.ExitCodeError {
+			t.Fatalf("unexpected exit code: %v", result.ExitCode)
+		}
+	})
+
+	t.Run("should keep the file private once it holds an API key", func(t *testing.T) {
+		configurationFilePath, config := newTestConfig(t)
+		err := os.Chmod(configurationFilePath, 0644)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		executeConfig(t, config, "set", "ai_providers.openai.api_key", "sk-secret")
+		fileInfo, err := os.Stat(configurationFilePath)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if fileInfo.Mode().Perm() != 0600 {
+			t.Fatalf("expected permissions 0600, got: %v", fileInfo.Mode().Perm())
+		}
+	})
+
+	t.Run("should list every value with masked API keys", func(t *testing.T) {
+		_, config := newTestConfig(t)
+		executeConfig(t, config, "set", "ai_providers.openai.api_key", "sk-secret")
+		result := executeConfig(t, config, "list")
+		payload, isListPayload := result.Payload.(*ConfigListPayload)
+		if !isListPayload || payload.Values["ai_providers.openai.api_key"] != "********" || payload.Values["default_ai_provider"] != "openai" {
+			t.Fatalf("expected the payload to hold the masked values, got: %+v", result.Payload)
+		}
+		message := result.Message.StripMarkup()
+		for _, expected := range []string{"default_ai_provider=openai", "ai_providers.openai.api_key=********"} {
+			if !strings.Contains(message, expected) {
+				t.Fatalf("expected message to contain %q, got: %q", expected, message)
+			}
+		}
+		if strings.Contains(message, "sk-secret") {
+			t.Fatalf("expected the API key to be masked, got: %q", message)
+		}
+	})
+
+	t.Run("should report invalid references on validate", func(t *testing.T) {
+		configurationFilePath, config := newTestConfig(t)
+		result := executeConfig(t, config, "validate")
+		if result.ExitCode != vo.ExitCodeSuccess {
+			t.Fatalf("unexpected exit code: %v, message: %q", result.ExitCode, result.Message.StripMarkup())
+		}
+		err := os.WriteFile(configurationFilePath, []byte(`{"default_ai_provider": "anthropic", "default_language": "en_US"}`), 0644)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		result = executeConfig(t, config, "validate")
+		if result.ExitCode != vo.ExitCodeConfiguration {
+			t.Fatalf("unexpected exit code: %v", result.ExitCode)
+		}
+		if !strings.Contains(result.Message.StripMarkup(), "anthropic") {
+			t.Fatalf("expected message to mention the provider, got: %q", result.Message.StripMarkup())
+		}
+		payload, isValidatePayload := result.Payload.(*ConfigValidatePayload)
+		if !isValidatePayload || payload.Valid || len(payload.Errors) == 0 || !strings.Contains(payload.Errors[0], "anthropic") {
+			t.Fatalf("expected the payload to list the errors, got: %+v", result.Payload)
+		}
+	})
+
+	t.Run("should print the file path", func(t *testing.T) {
+		configurationFilePath, config := newTestConfig(t)
+		result := executeConfig(t, config, "path")
+		if payload, isPathPayload := result.Payload.(*ConfigPathPayload); !isPathPayload || payload.File != configurationFilePath {
+			t.Fatalf("expected payload with file %q, got: %+v", configurationFilePath, result.Payload)
+		}
+	})
+
+	t.Run("should reject unknown subcommands", func(t *testing.T) {
+		_, config := newTestConfig(t)
+		result := executeConfig(t, config, "lst")
+		if result.ExitCode != vo.ExitCodeCommandNotFound {
+			t.Fatalf("unexpected exit code: %v", result.ExitCode)
+		}
+		if !strings.Contains(result.Message.StripMarkup(), `Did you mean "list"?`) {
+			t.Fatalf("expected a suggestion, got: %q", result.Message.StripMarkup())
+		}
+	})
+
+	t.Run("should require the key", func(t *testing.T) {
+		_, config := newTestConfig(t)
+		result := executeConfig(t, config, "get")
+		if result.ExitCode != vo.ExitCodeInvalidUsage {
+			t.Fatalf("unexpected exit code: %v", result.ExitCode)
+		}
+	})
+}
+
+const legacyConfiguration = `{
+	"default_ai_provider": "openai",
+	"default_language": "en_US",
+	"ai_providers": {"openai": {"api_key": "", "models": ["gpt-4.1"], "default_model": "gpt-4.1"}},
+	"languages": {"en_US": {"id": "en_US", "display_name": "English (United States)"}}
+}`
+
+func TestConfigSchema(t *testing.T) {
+	t.Run("should migrate files without a schema version in memory", func(t *testing.T) {
+		configurationFilePath := filepath.Join(t.TempDir(), "commit.json")
+		err := os.WriteFile(configurationFilePath, []byte(legacyConfiguration), 0600)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		config := NewConfig(configurationFilePath)
+		result := executeConfig(t, config, "get", "schema_version")
+		if result.Message.StripMarkup() != "1" {
+			t.Fatalf("expected schema version 1, got: %q", result.Message.StripMarkup())
+		}
+		result = executeConfig(t, config, "get", "ai_providers.openai.id")
+		if result.Message.StripMarkup() != "openai" {
+			t.Fatalf("expected the provider ID to be filled, got: %q", result.Message.StripMarkup())
+		}
+		if readTestConfiguration(t, configurationFilePath) != legacyConfiguration {
+			t.Fatalf("expected the file to be left untouched when read")
+		}
+	})
+
+	t.Run("should migrate the file and keep a backup on migrate", func(t *testing.T) {
+		configurationFilePath := filepath.Join(t.TempDir(), "commit.json")
+		err := os.WriteFile(configurationFilePath, []byte(legacyConfiguration), 0600)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		config := NewConfig(configurationFilePath)
+		result := executeConfig(t, config, "migrate")
+		if !strings.Contains(result.Message.StripMarkup(), "from schema version 0 to 1") {
+			t.Fatalf("expected the migration to be reported, got: %q", result.Message.StripMarkup())
+		}
+		if !strings.Contains(readTestConfiguration(t, configurationFilePath), `"schema_version": 1`) {
+			t.Fatalf("expected the file to be upgraded, got: %q", readTestConfiguration(t, configurationFilePath))
+		}
+		backupFilePaths, err := filepath.Glob(configurationFilePath + ".v0.*.bak")
+		if err != nil || len(backupFilePaths) != 1 {
+			t.Fatalf("expected a single backup, got: %v, %v", backupFilePaths, err)
+		}
+		if readTestConfiguration(t, backupFilePaths[0]) != legacyConfiguration {
+			t.Fatalf("expected the backup to keep the original content")
+		}
+		expectedPayload := ConfigMigratePayload{FromSchemaVersion: 0, ToSchemaVersion: 1, BackupFile: backupFilePaths[0]}
+		if payload, isMigratePayload := result.Payload.(*ConfigMigratePayload); !isMigratePayload || *payload != expectedPayload {
+			t.Fatalf("expected payload %+v, got: %+v", expectedPayload, result.Payload)
+		}
+		fileInfo, err := os.Stat(configurationFilePath)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if fileInfo.Mode().Perm() != 0600 {
+			t.Fatalf("expected the permissions to be kept, got: %v", fileInfo.Mode().Perm())
+		}
+		result = executeConfig(t, config, "migrate")
+		if !strings.Contains(result.Message.StripMarkup(), "already uses schema version 1") {
+			t.Fatalf("expected the file to be current, got: %q", result.Message.StripMarkup())
+		}
+	})
+
+	t.Run("should keep a backup when set rewrites an old file", func(t *testing.T) {
+		configurationFilePath := filepath.Join(t.TempDir(), "commit.json")
+		err := os.WriteFile(configurationFilePath, []byte(legacyConfiguration), 0644)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		executeConfig(t, NewConfig(configurationFilePath), "set", "ai_providers.openai.api_key", "sk-secret")
+		backupFilePaths, err := filepath.Glob(configurationFilePath + ".v0.*.bak")
+		if err != nil || len(backupFilePaths) != 1 {
+			t.Fatalf("expected a single backup, got: %v, %v", backupFilePaths, err)
+		}
+		fileInfo, err := os.Stat(backupFilePaths[0])
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if fileInfo.Mode().Perm() != 0600 {
+			t.Fatalf("expected the backup of a file holding an API key to be private, got: %v", fileInfo.Mode().Perm())
+		}
+	})
+
+	t.Run("should reject a negative schema version", func(t *testing.T) {
+		configurationFilePath := filepath.Join(t.TempDir(), "commit.json")
+		err := os.WriteFile(configurationFilePath, []byte(`{"schema_version": -1}`), 0644)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		_, err = dispatchConfig(NewConfig(configurationFilePath), "get", "schema_version")
+		if err == nil || !strings.Contains(err.Error(), "non-negative") {
+			t.Fatalf("expected a non-negative error, got: %v", err)
+		}
+	})
+
+	t.Run("should reject files from a newer release", func(t *testing.T) {
+		configurationFilePath := filepath.Join(t.TempDir(), "commit.json")
+		err := os.WriteFile(configurationFilePath, []byte(`{"schema_version": 99}`), 0644)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		_, err = dispatchConfig(NewConfig(configurationFilePath), "get", "schema_version")
+		if err == nil || !strings.Contains(err.Error(), "upgrade") {
+			t.Fatalf("expected an upgrade error, got: %v", err)
+		}
+	})
+
+	t.Run("should report unknown keys with suggestions", func(t *testing.T) {
+		configurationFilePath, config := newTestConfig(t)
+		data, err := os.ReadFile(configurationFilePath)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		content := strings.Replace(string(data), `"default_model"`, `"defualt_model"`, 1)
+		content = strings.Replace(content, `"branch_pattern"`, `"brnch_pattern"`, 1)
+		err = os.WriteFile(configurationFilePath, []byte(content), 0644)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		message := executeConfig(t, config, "validate").Message.StripMarkup()
+		for _, expected := range []string{
+			`unknown key "ai_providers.openai.defualt_model", did you mean "ai_providers.openai.default_model"?`,
+			`unknown key "brnch_pattern", did you mean "branch_pattern"?`,
+		} {
+			if !strings.Contains(message, expected) {
+				t.Fatalf("expected message to contain %q, got: %q", expected, message)
+			}
+		}
+	})
+}
+
+func TestConfigFormats(t *testing.T) {
+	for _, fileName := range []string{"commit.yaml", "commit.yml", "commit.toml"} {
+		t.Run("should be able to create and edit "+fileName, func(t *testing.T) {
+			configurationFilePath := filepath.Join(t.TempDir(), fileName)
+			_, err := newTestInit(t, configurationFilePath).Execute(newInitInput(map[string]string{"language": "pt_BR"}))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if strings.HasPrefix(readTestConfiguration(t, configurationFilePath), "{") {
+				t.Fatalf("expected %s not to be written as JSON", fileName)
+			}
+			config := NewConfig(configurationFilePath)
+			executeConfig(t, config, "set", "history_examples.count", "3")
+			for key, expected := range map[string]string{
+				"default_language":                  "pt_BR",
+				"history_examples.count":            "3",
+				"ai_providers.openai.default_model": "gpt-4.1",
+			} {
+				result := executeConfig(t, config, "get", key)
+				if result.Message.StripMarkup() != expected {
+					t.Fatalf("expected %s to be %q, got: %q", key, expected, result.Message.StripMarkup())
+				}
+			}
+			result := executeConfig(t, config, "validate")
+			if result.ExitCode != vo.ExitCodeSuccess {
+				t.Fatalf("unexpected exit code: %v, message: %q", result.ExitCode, result.Message.StripMarkup())
+			}
+		})
+	}
+
+	t.Run("should read commented YAML without rewriting it", func(t *testing.T) {
+		configurationFilePath := filepath.Join(t.TempDir(), "commit.yaml")
+		content := "# Team defaults\ndefault_ai_provider: openai # the only one we pay for\ndefault_language: en_US\nscopes: [api, web]\n"
+		err := os.WriteFile(configurationFilePath, []byte(content), 0644)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		result := executeConfig(t, NewConfig(configurationFilePath), "get", "scopes")
+		if result.Message.StripMarkup() != `["api","web"]` {
+			t.Fatalf("expected %q, got: %q", `["api","web"]`, result.Message.StripMarkup())
+		}
+		if readTestConfiguration(t, configurationFilePath) != content {
+			t.Fatalf("expected the comments to be kept")
+		}
+	})
+}
+
+func TestConfigProfiles(t *testing.T) {
+	t.Run("should report profiles referring to missing entries", func(t *testing.T) {
+		_, config := newTestConfig(t)
+		executeConfig(t, config, "set", "profiles.work", `{"default_language": "de_DE", "scopes": ["api"]}`)
+		executeConfig(t, config, "set", "profile_rules", `[{"path": "~/work", "profile": "wrok"}, {"path": "work", "profile": "work"}]`)
+		result := executeConfig(t, config, "validate")
+		if result.ExitCode != vo.ExitCodeConfiguration {
+			t.Fatalf("unexpected exit code: %v", result.ExitCode)
+		}
+		for _, expected := range []string{
+			`profiles.work.default_language "de_DE" is not in languages`,
+			`profile_rules[0].profile "wrok" is not in profiles`,
+			`profile_rules[1].path "work" must be absolute or start with ~`,
+		} {
+			if !strings.Contains(result.Message.StripMarkup(), expected) {
+				t.Fatalf("expected message to contain %q, got: %q", expected, result.Message.StripMarkup())
+			}
+		}
+	})
+}
@@ -1,31 +1,67 @@
 package command
 
 import (
-	"bytes"
 	"errors"
 	"fmt"
-	"os/exec"
 
 	"github.com/yusadeol/go-commit/internal/adapter/cli/dispatcher"
 
 	"github.com/yusadeol/go-commit/internal/app/usecase"
 	"github.com/yusadeol/go-commit/internal/domain/vo"
 	"github.com/yusadeol/go-commit/internal/infra/service/ai"
+	"github.com/yusadeol/go-commit/internal/infra/service/credential"
+	"github.com/yusadeol/go-commit/internal/infra/service/git"
 )
 
+// GeneratePayload is the structured output of generate. Type and scope are
+// empty when the message is not a Conventional Commit.
+type GeneratePayload struct {
+	Message   string        `json:"message"`
+	Type      string        `json:"type"`
+	Scope     string        `json:"scope"`
+	Breaking  bool          `json:"breaking"`
+	Provider  string        `json:"provider"`
+	Model     string        `json:"model"`
+	Usage     ai.TokenUsage `json:"usage"`
+	Committed bool          `json:"committed"`
+}
+
 type Generate struct {
 	configuration            *vo.Configuration
 	aiDefaultProviderFactory ai.ProviderFactory
+	credentialResolver       credential.Resolver
+	git                      *git.Git
+}
+
+func NewGenerate(
+	aiDefaultProviderFactory ai.ProviderFactory,
+	credentialResolver credential.Resolver,
+	git *git.Git,
+) *Generate {
+	return &Generate{
+		configuration:            &vo.Configuration{},
+		aiDefaultProviderFactory: aiDefaultProviderFactory,
+		credentialResolver:       credentialResolver,
+		git:                      git,
+	}
 }
 
-func NewGenerate(configuration *vo.Configuration, aiDefaultProviderFactory ai.ProviderFactory) *Generate {
-	return &Generate{configuration: configuration, aiDefaultProviderFactory: aiDefaultProviderFactory}
+func (g *Generate) SetConfiguration(configuration *vo.Configuration) {
+	g.configuration = configuration
 }
 
 func (g *Generate) GetName() string {
 	return "generate"
 }
 
+func (g *Generate) GetDescription() string {
+	return "Generate a commit message for the staged changes"
+}
+
+func (g *Generate) GetAliases() []string {
+	return []string{"g"}
+}
+
 func (g *Generate) GetArguments() []dispatcher.Argument {
 	return []dispatcher.Argument{
 		{Name: "diff", Description: "Git diff", Required: false},
@@ -33,74 +69,82 @@ func (g *Generate) GetArguments() []dispatcher.Argument {
 }
 
 func (g *Generate) GetOptions() []dispatcher.Option {
-	languageAllowedValues := g.GetLanguageAllowedValues()
-	return []dispatcher.Option{
-		{
-			Name:          "provider",
-			Flag:          "p",
-			Description:   "AI Provider",
-			AllowedValues: []string{"openai"},
-			Default:       g.configuration.DefaultAIProvider,
-		},
-		{
-			Name:          "language",
-			Flag:          "l",
-			Description:   "Language",
-			AllowedValues: languageAllowedValues,
-			Default:       g.configuration.DefaultLanguage,
+	return append(getAIOptions(g.configuration),
+		dispatcher.Option{
+			Name:        "commit",
+			Flag:        "c",
+			Description: "Commit",
+			Type:        dispatcher.OptionTypeBool,
+			Default:     "true",
 		},
-		{
-			Name:          "commit",
-			Flag:          "c",
-			Description:   "Commit",
-			AllowedValues: []string{"true", "false"},
-			Default:       "true",
+		dispatcher.Option{
+			Name:        "examples",
+			Flag:        "e",
+			Description: "Use recent commits as style examples",
+			Type:        dispatcher.OptionTypeBool,
+			Default:     "true",
 		},
-	}
-}
-
-func (g *Generate) GetLanguageAllowedValues() []string {
-	allowedValues := make([]string, 0, len(g.configuration.Languages))
-	for language := range g.configuration.Languages {
-		allowedValues = append(allowedValues, language)
-	}
-	return allowedValues
+	)
 }
 
 func (g *Generate) Execute(input *dispatcher.CommandInput) (*dispatcher.Result, error) {
 	result := dispatcher.NewResult()
-	configurationAIProvider, configurationAIProviderExists := g.configuration.AIProviders[input.Options["provider"].Value]
-	if !configurationAIProviderExists {
-		return nil, fmt.Errorf("AI provider %q configuration not found", input.Options["provider"].Value)
-	}
-	configurationLanguage, configurationLanguageExists := g.configuration.Languages[input.Options["language"].Value]
-	if !configurationLanguageExists {
-		return nil, fmt.Errorf("language %q configuration not found", input.Options["language"].Value)
+	configurationAIProvider, configurationLanguage, err := getAIConfiguration(g.configuration, g.credentialResolver, input)
+	if err != nil {
+		return nil, err
 	}
 	diff := input.Arguments["diff"].Value
 	if diff == "" {
-		var err error
 		diff, err = g.getGitDiff()
 		if err != nil {
 			return nil, err
 		}
 	}
-	generate := usecase.NewGenerate()
-	output, err := generate.Execute(&usecase.GenerateInput{
+	generateInput := &usecase.GenerateInput{
 		AIDefaultProviderFactory: g.aiDefaultProviderFactory,
-		AIProvider:               &configurationAIProvider,
-		Language:                 &configurationLanguage,
+		AIProvider:               configurationAIProvider,
+		Language:                 configurationLanguage,
 		Diff:                     diff,
-	})
+		Prompt:                   g.configuration.Prompt,
+		Types:                    g.configuration.Types,
+		Scopes:                   g.configuration.Scopes,
+	}
+	if g.configuration.Ticket.Pattern != "" {
+		// A diff given outside a repository has no branch to read the
+		// ticket from, so the reference is just skipped.
+		branch, err := g.git.CurrentBranch()
+		if err == nil {
+			generateInput.Ticket = &g.configuration.Ticket
+			generateInput.Branch = branch
+		}
+	}
+	if g.configuration.HistoryExamples.Enabled && input.Options["examples"].Bool() {
+		generateInput.Examples = getStyleExamples(g.git, g.configuration.HistoryExamples.Count)
+	}
+	generate := usecase.NewGenerate()
+	output, err := generate.Execute(generateInput)
 	if err != nil {
 		return nil, err
 	}
-	if input.Options["commit"].Value == "true" {
-		err = g.commitChanges(output.Commit)
+	payload := &GeneratePayload{
+		Message:  output.Commit,
+		Provider: configurationAIProvider.ID,
+		Model:    configurationAIProvider.DefaultModel,
+		Usage:    output.Usage,
+	}
+	if conventionalCommit, err := vo.ParseConventionalCommit(output.Commit); err == nil {
+		payload.Type = conventionalCommit.Type
+		payload.Scope = conventionalCommit.Scope
+		payload.Breaking = conventionalCommit.Breaking
+	}
+	if input.Options["commit"].Bool() {
+		err = g.git.Commit(output.Commit)
 		if err != nil {
 			return nil, err
 		}
+		payload.Committed = true
 	}
+	result.Payload = payload
 	message := []string{
 		"<info>Commit generated and applied successfully!</info>",
 		fmt.Sprintf("<comment>%s</comment>", output.Commit),
@@ -110,37 +154,44 @@ func (g *Generate) Execute(input *dispatcher.CommandInput) (*dispatcher.Result,
 }
 
 func (g *Generate) getGitDiff() (string, error) {
-	var out bytes.Buffer
-	var outErr bytes.Buffer
-	cmd := exec.Command("git", "diff", "--staged")
-	cmd.Stdout = &out
-	cmd.Stderr = &outErr
-	err := cmd.Run()
-	if err != nil {
-		if outErr.Len() > 0 {
-			return "", errors.New(outErr.String())
+	args := []string{"--staged"}
+	if len(g.configuration.Excludes) > 0 {
+		args = append(args, "--", ":/")
+		for _, exclude := range g.configuration.Excludes {
+			args = append(args, ":(top,exclude)"+exclude)
 		}
+	}
+	diff, err := g.git.Diff(args...)
+	if err != nil {
 		return "", err
 	}
-	diff := out.String()
 	if diff == "" {
 		return "", errors.New("no staged changes found")
 	}
 	return diff, nil
 }
 
-func (g *Generate) commitChanges(commit string) error {
-	var out bytes.Buffer
-	var outErr bytes.Buffer
-	cmd := exec.Command("git", "commit", "-m", commit)
-	cmd.Stdout = &out
-	cmd.Stderr = &outErr
-	err := cmd.Run()
+// getStyleExamples returns up to count recent commit messages that follow the
+// Conventional Commits specification. Repositories without history simply
+// yield no examples.
+func getStyleExamples(git *git.Git, count int) []string {
+	if count <= 0 {
+		return nil
+	}
+	commits, err := git.RecentCommits(count * 10)
 	if err != nil {
-		if outErr.Len() > 0 {
-			return errors.New(outErr.String())
+		return nil
+	}
+	examples := make([]string, 0, count)
+	for _, commit := range commits {
+		if len(examples) == count {
+			break
+		}
+		_, err = vo.ParseConventionalCommit(commit.Message)
+		if err != nil {
+			continue
 		}
-		return err
+		examples = append(examples, commit.Message)
 	}
-	return nil
+	return examples
 }
@@ -1,6 +1,8 @@
 package command
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -8,6 +10,8 @@ import (
 
 	"github.com/yusadeol/go-commit/internal/domain/vo"
 	"github.com/yusadeol/go-commit/internal/infra/service/ai"
+	"github.com/yusadeol/go-commit/internal/infra/service/credential"
+	"github.com/yusadeol/go-commit/internal/infra/service/git"
 )
 
 const mockDiff = `
@@ -40,6 +44,7 @@ func (m *MockProvider) Ask(input *ai.ProviderInput) (*ai.ProviderOutput, error)
 	return &ai.ProviderOutput{
 		Status: "success",
 		Text:   "feat: rename function and update greeting message",
+		Usage:  ai.TokenUsage{InputTokens: 120, OutputTokens: 12, TotalTokens: 132},
 	}, nil
 }
 
@@ -49,6 +54,26 @@ func (m *MockDefaultProviderFactory) Create(id string, apiKey string) (ai.Provid
 	return &MockProvider{}, nil
 }
 
+type MockRecordingProviderFactory struct {
+	apiKeys []string
+	inputs  []*ai.ProviderInput
+}
+
+func (m *MockRecordingProviderFactory) Create(id string, apiKey string) (ai.Provider, error) {
+	m.apiKeys = append(m.apiKeys, apiKey)
+	return m, nil
+}
+
+func (m *MockRecordingProviderFactory) Ask(input *ai.ProviderInput) (*ai.ProviderOutput, error) {
+	m.inputs = append(m.inputs, input)
+	return (&MockProvider{}).Ask(input)
+}
+
+func newTestCredentialResolver(t *testing.T) credential.Resolver {
+	t.Helper()
+	return credential.NewDefaultResolver(credential.NewFileKeyring(filepath.Join(t.TempDir(), "commit-credentials.json")))
+}
+
 func TestGenerate(t *testing.T) {
 	t.Run("should be able to generate a commit", func(t *testing.T) {
 		mockConfiguration := vo.Configuration{
@@ -63,7 +88,8 @@ func TestGenerate(t *testing.T) {
 				"en_US": {ID: "en_US", DisplayName: "English (US)"},
 			},
 		}
-		generate := NewGenerate(&mockConfiguration, &MockDefaultProviderFactory{})
+		generate := NewGenerate(&MockDefaultProviderFactory{}, newTestCredentialResolver(t), git.New(t.TempDir()))
+		generate.SetConfiguration(&mockConfiguration)
 		result, err := generate.Execute(&dispatcher.CommandInput{
 			Arguments: map[string]dispatcher.ArgumentInput{
 				"diff": {Value: mockDiff, Meta: dispatcher.Argument{Name: "diff", Description: "Git diff", Required: false}},
@@ -84,5 +110,224 @@ func TestGenerate(t *testing.T) {
 		if !strings.Contains(result.Message.StripMarkup(), expected) {
 			t.Fatalf("expected message to contain %q, got: %q", expected, result.Message.StripMarkup())
 		}
+		expectedPayload := GeneratePayload{
+			Message:  expected,
+			Type:     "feat",
+			Provider: "mock",
+			Model:    "mock-model",
+			Usage:    ai.TokenUsage{InputTokens: 120, OutputTokens: 12, TotalTokens: 132},
+		}
+		payload, isGeneratePayload := result.Payload.(*GeneratePayload)
+		if !isGeneratePayload || *payload != expectedPayload {
+			t.Fatalf("expected payload %+v, got: %+v", expectedPayload, result.Payload)
+		}
+	})
+	t.Run("should add the ticket from the branch name as a footer", func(t *testing.T) {
+		repositoryDirPath, repository := newTestRepository(t)
+		commitTestFile(t, repositoryDirPath, repository, "a.txt", "chore: initial commit")
+		_, err := repository.Run("switch", "-c", "feature/PROJ-1234-add-login")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		mockConfiguration := vo.Configuration{
+			AIProviders: map[string]vo.AIProvider{
+				"mock": {ID: "mock", APIKey: "fake-api-key", DefaultModel: "mock-model"},
+			},
+			Languages: map[string]vo.Language{
+				"en_US": {ID: "en_US", DisplayName: "English (US)"},
+			},
+			Ticket: vo.Ticket{Pattern: "[A-Z]+-[0-9]+", Placement: vo.TicketPlacementFooter, FooterToken: "Refs"},
+		}
+		generate := NewGenerate(&MockDefaultProviderFactory{}, newTestCredentialResolver(t), repository)
+		generate.SetConfiguration(&mockConfiguration)
+		result, err := generate.Execute(&dispatcher.CommandInput{
+			Arguments: map[string]dispatcher.ArgumentInput{
+				"diff": {Value: mockDiff},
+			},
+			Options: map[string]dispatcher.OptionInput{
+				"provider": {Value: "mock"},
+				"language": {Value: "en_US"},
+				"commit":   {Value: "false"},
+			},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := "feat: rename function and update greeting message\n\nRefs: PROJ-1234"
+		if !strings.Contains(result.Message.StripMarkup(), expected) {
+			t.Fatalf("expected message to contain %q, got: %q", expected, result.Message.StripMarkup())
+		}
+	})
+	t.Run("should use recent conventional commits as style examples", func(t *testing.T) {
+		repositoryDirPath, repository := newTestRepository(t)
+		commitTestFile(t, repositoryDirPath, repository, "a.txt", "feat(api): add users endpoint")
+		commitTestFile(t, repositoryDirPath, repository, "b.txt", "wip")
+		commitTestFile(t, repositoryDirPath, repository, "c.txt", "fix(api): validate user input")
+		mockConfiguration := vo.Configuration{
+			AIProviders: map[string]vo.AIProvider{
+				"mock": {ID: "mock", APIKey: "fake-api-key", DefaultModel: "mock-model"},
+			},
+			Languages: map[string]vo.Language{
+				"en_US": {ID: "en_US", DisplayName: "English (US)"},
+			},
+			HistoryExamples: vo.HistoryExamples{Enabled: true, Count: 5},
+		}
+		providerFactory := &MockRecordingProviderFactory{}
+		generate := NewGenerate(providerFactory, newTestCredentialResolver(t), repository)
+		generate.SetConfiguration(&mockConfiguration)
+		_, err := generate.Execute(&dispatcher.CommandInput{
+			Arguments: map[string]dispatcher.ArgumentInput{
+				"diff": {Value: mockDiff},
+			},
+			Options: map[string]dispatcher.OptionInput{
+				"provider": {Value: "mock"},
+				"language": {Value: "en_US"},
+				"commit":   {Value: "false"},
+				"examples": {Value: "true"},
+			},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		instructions := providerFactory.inputs[0].Instructions
+		for _, expected := range []string{"feat(api): add users endpoint", "fix(api): validate user input"} {
+			if !strings.Contains(instructions, expected) {
+				t.Fatalf("expected instructions to contain %q, got: %q", expected, instructions)
+			}
+		}
+		if strings.Contains(instructions, "wip") {
+			t.Fatalf("expected non conventional commits to be skipped, got: %q", instructions)
+		}
+	})
+	t.Run("should leave excluded files out of the staged diff", func(t *testing.T) {
+		repositoryDirPath, repository := newTestRepository(t)
+		commitTestFile(t, repositoryDirPath, repository, "a.txt", "chore: initial commit")
+		for _, fileName := range []string{"main.go", "go.sum"} {
+			err := os.WriteFile(filepath.Join(repositoryDirPath, fileName), []byte(fileName), 0644)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+		_, err := repository.Run("add", ".")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		mockConfiguration := vo.Configuration{
+			AIProviders: map[string]vo.AIProvider{
+				"mock": {ID: "mock", APIKey: "fake-api-key", DefaultModel: "mock-model"},
+			},
+			Languages: map[string]vo.Language{
+				"en_US": {ID: "en_US", DisplayName: "English (US)"},
+			},
+			Excludes: []string{"go.sum"},
+		}
+		providerFactory := &MockRecordingProviderFactory{}
+		generate := NewGenerate(providerFactory, newTestCredentialResolver(t), repository)
+		generate.SetConfiguration(&mockConfiguration)
+		_, err = generate.Execute(&dispatcher.CommandInput{
+			Options: map[string]dispatcher.OptionInput{
+				"provider": {Value: "mock"},
+				"language": {Value: "en_US"},
+				"commit":   {Value: "false"},
+			},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		diff := providerFactory.inputs[0].Input
+		if !strings.Contains(diff, "main.go") || strings.Contains(diff, "go.sum") {
+			t.Fatalf("expected diff to contain only main.go, got: %q", diff)
+		}
+	})
+	t.Run("should resolve the API key from the configured source", func(t *testing.T) {
+		t.Setenv("COMMIT_TEST_API_KEY", "env-api-key")
+		keyringFilePath := filepath.Join(t.TempDir(), "commit-credentials.json")
+		err := os.WriteFile(keyringFilePath, []byte(`{"keyring": "keyring-api-key"}`), 0600)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		tests := []struct {
+			name       string
+			aiProvider vo.AIProvider
+			expected   string
+		}{
+			{name: "plaintext", aiProvider: vo.AIProvider{ID: "plaintext", APIKey: "plaintext-api-key"}, expected: "plaintext-api-key"},
+			{name: "environment", aiProvider: vo.AIProvider{ID: "environment", APIKey: "ignored", APIKeyEnv: "COMMIT_TEST_API_KEY"}, expected: "env-api-key"},
+			{name: "command", aiProvider: vo.AIProvider{ID: "command", APIKeyCommand: "printf 'command-api-key\\nuser: me'"}, expected: "command-api-key"},
+			{name: "keyring", aiProvider: vo.AIProvider{ID: "keyring", APIKeyKeyring: true}, expected: "keyring-api-key"},
+		}
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				mockConfiguration := vo.Configuration{
+					AIProviders: map[string]vo.AIProvider{test.aiProvider.ID: test.aiProvider},
+					Languages: map[string]vo.Language{
+						"en_US": {ID: "en_US", DisplayName: "English (US)"},
+					},
+				}
+				providerFactory := &MockRecordingProviderFactory{}
+				credentialResolver := credential.NewDefaultResolver(credential.NewFileKeyring(keyringFilePath))
+				generate := NewGenerate(providerFactory, credentialResolver, git.New(t.TempDir()))
+				generate.SetConfiguration(&mockConfiguration)
+				_, err := generate.Execute(&dispatcher.CommandInput{
+					Arguments: map[string]dispatcher.ArgumentInput{
+						"diff": {Value: mockDiff},
+					},
+					Options: map[string]dispatcher.OptionInput{
+						"provider": {Value: test.aiProvider.ID},
+						"language": {Value: "en_US"},
+						"commit":   {Value: "false"},
+					},
+				})
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if providerFactory.apiKeys[0] != test.expected {
+					t.Fatalf("expected API key %q, got: %q", test.expected, providerFactory.apiKeys[0])
+				}
+			})
+		}
+	})
+	t.Run("should override the provider default model", func(t *testing.T) {
+		mockConfiguration := vo.Configuration{
+			AIProviders: map[string]vo.AIProvider{
+				"mock": {ID: "mock", APIKey: "fake-api-key", DefaultModel: "mock-model"},
+			},
+			Languages: map[string]vo.Language{
+				"en_US": {ID: "en_US", DisplayName: "English (US)"},
+			},
+		}
+		providerFactory := &MockRecordingProviderFactory{}
+		generate := NewGenerate(providerFactory, newTestCredentialResolver(t), git.New(t.TempDir()))
+		generate.SetConfiguration(&mockConfiguration)
+		_, err := generate.Execute(&dispatcher.CommandInput{
+			Arguments: map[string]dispatcher.ArgumentInput{
+				"diff": {Value: mockDiff},
+			},
+			Options: map[string]dispatcher.OptionInput{
+				"provider": {Value: "mock"},
+				"model":    {Value: "other-model"},
+				"language": {Value: "en_US"},
+				"commit":   {Value: "false"},
+			},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if providerFactory.inputs[0].Model != "other-model" {
+			t.Fatalf("expected model %q, got: %q", "other-model", providerFactory.inputs[0].Model)
+		}
+	})
+
+	t.Run("should list the configured languages in order", func(t *testing.T) {
+		configuration := &vo.Configuration{
+			Languages: map[string]vo.Language{"pt_BR": {}, "en_US": {}, "es_ES": {}, "de_DE": {}},
+		}
+		expected := "de_DE, en_US, es_ES, pt_BR"
+		for range 10 {
+			languageOption := getLanguageOption(configuration)
+			if strings.Join(languageOption.AllowedValues, ", ") != expected {
+				t.Fatalf("expected allowed values %q, got: %v", expected, languageOption.AllowedValues)
+			}
+		}
 	})
 }
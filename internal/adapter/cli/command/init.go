@@ -2,47 +2,205 @@ package command
 
 import (
 	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
 
 	"github.com/yusadeol/go-commit/internal/adapter/cli/dispatcher"
 
 	"github.com/yusadeol/go-commit/internal/app/usecase"
 	"github.com/yusadeol/go-commit/internal/domain/vo"
+	"github.com/yusadeol/go-commit/internal/infra/service/credential"
 )
 
+var apiKeySources = []string{
+	usecase.APIKeySourceEnv,
+	usecase.APIKeySourceCommand,
+	usecase.APIKeySourceKeyring,
+	usecase.APIKeySourcePlaintext,
+}
+
+// InitPayload is the structured output of init. Created is false when an
+// existing file was left unchanged.
+type InitPayload struct {
+	File    string `json:"file"`
+	Created bool   `json:"created"`
+}
+
 type Init struct {
-	configurationDirPath string
+	configurationFilePath string
+	keyring               credential.Keyring
+	prompter              *Prompter
+	interactive           bool
 }
 
-func NewInit(configurationDirPath string) *Init {
-	return &Init{configurationDirPath: configurationDirPath}
+// NewInit asks its questions on stderr, keeping stdout for the result so it
+// can still be read by scripts, such as with --output=json.
+func NewInit(configurationFilePath string, keyring credential.Keyring) *Init {
+	return &Init{
+		configurationFilePath: configurationFilePath,
+		keyring:               keyring,
+		prompter:              NewPrompter(os.Stdin, os.Stderr),
+		interactive:           isTerminal(os.Stdin) && isTerminal(os.Stderr),
+	}
 }
 
 func (g *Init) GetName() string {
 	return "init"
 }
 
+func (g *Init) GetDescription() string {
+	return "Create the configuration file"
+}
+
 func (g *Init) GetArguments() []dispatcher.Argument {
 	return []dispatcher.Argument{}
 }
 
 func (g *Init) GetOptions() []dispatcher.Option {
-	return []dispatcher.Option{}
+	defaultConfiguration := usecase.NewDefaultConfiguration()
+	return []dispatcher.Option{
+		{
+			Name:        "force",
+			Flag:        "f",
+			Description: "Overwrite an existing configuration file",
+			Type:        dispatcher.OptionTypeBool,
+			Default:     "false",
+		},
+		{
+			Name:          "provider",
+			Flag:          "p",
+			Description:   "Default AI provider",
+			AllowedValues: slices.Sorted(maps.Keys(defaultConfiguration.AIProviders)),
+		},
+		{
+			Name:          "language",
+			Flag:          "l",
+			Description:   "Default language",
+			AllowedValues: slices.Sorted(maps.Keys(defaultConfiguration.Languages)),
+		},
+		{
+			Name:        "model",
+			Flag:        "m",
+			Description: "Default model of the AI provider",
+		},
+		{
+			Name:          "api-key-source",
+			Description:   "Where the API key is read from",
+			AllowedValues: apiKeySources,
+		},
+		{
+			Name:        "api-key",
+			Description: "API key, or the variable name for env and the command line for command",
+		},
+	}
 }
 
+// Execute asks for every setting not given as an option when running on a
+// terminal, so scripts can pass all of them and skip the wizard.
 func (g *Init) Execute(input *dispatcher.CommandInput) (*dispatcher.Result, error) {
 	result := dispatcher.NewResult()
+	payload := &InitPayload{File: g.configurationFilePath}
+	result.Payload = payload
+	createConfigurationFileInput := &usecase.CreateConfigurationFileInput{
+		ConfigurationFilePath: g.configurationFilePath,
+		Force:                 input.Options["force"].Bool(),
+		AIProvider:            input.Options["provider"].Value,
+		Language:              input.Options["language"].Value,
+		Model:                 input.Options["model"].Value,
+		APIKeySource:          input.Options["api-key-source"].Value,
+		APIKey:                input.Options["api-key"].Value,
+		Keyring:               g.keyring,
+	}
+	if g.interactive {
+		_, err := os.Stat(g.configurationFilePath)
+		if err == nil && !createConfigurationFileInput.Force {
+			overwrite, err := g.prompter.Confirm(fmt.Sprintf("%s already exists. Overwrite it?", g.configurationFilePath), false)
+			if err != nil {
+				return nil, err
+			}
+			if !overwrite {
+				result.Message = vo.NewMarkupText("<info>configuration file left unchanged</info>")
+				return result, nil
+			}
+			createConfigurationFileInput.Force = true
+		}
+		err = g.runWizard(createConfigurationFileInput)
+		if err != nil {
+			return nil, err
+		}
+	}
 	createConfigurationFile := usecase.NewCreateConfigurationFile()
-	err := createConfigurationFile.Execute(&usecase.CreateConfigurationFileInput{
-		ConfigurationDirPath: g.configurationDirPath,
-	})
+	err := createConfigurationFile.Execute(createConfigurationFileInput)
 	if errors.Is(err, usecase.ErrConfigurationAlreadyExists) {
 		result.ExitCode = vo.ExitCodeError
-		result.Message = vo.NewMarkupText("<info>configuration file already exists</info>")
+		result.Message = vo.NewMarkupText("<info>configuration file already exists, use --force to overwrite it</info>")
 		return result, nil
 	}
 	if err != nil {
 		return nil, err
 	}
 	result.Message = vo.NewMarkupText("<success>configuration file created successfully</success>")
+	payload.Created = true
 	return result, nil
 }
+
+func (g *Init) runWizard(input *usecase.CreateConfigurationFileInput) error {
+	defaultConfiguration := usecase.NewDefaultConfiguration()
+	var err error
+	if input.AIProvider == "" {
+		input.AIProvider, err = g.prompter.Choose(
+			"AI provider", slices.Sorted(maps.Keys(defaultConfiguration.AIProviders)), defaultConfiguration.DefaultAIProvider,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	if input.APIKeySource == "" {
+		input.APIKeySource, err = g.prompter.Choose("API key source", apiKeySources, usecase.APIKeySourceEnv)
+		if err != nil {
+			return err
+		}
+	}
+	if input.APIKey == "" {
+		switch input.APIKeySource {
+		case usecase.APIKeySourceEnv:
+			input.APIKey, err = g.prompter.Ask("Environment variable", strings.ToUpper(input.AIProvider)+"_API_KEY")
+		case usecase.APIKeySourceCommand:
+			input.APIKey, err = g.prompter.Ask("Command printing the API key", "")
+		default:
+			input.APIKey, err = g.prompter.AskSecret("API key")
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if input.Model == "" {
+		aiProvider := defaultConfiguration.AIProviders[input.AIProvider]
+		input.Model, err = g.prompter.Ask(
+			fmt.Sprintf("Model (%s)", strings.Join(aiProvider.Models, ", ")), aiProvider.DefaultModel,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	if input.Language == "" {
+		input.Language, err = g.prompter.Choose(
+			"Language", slices.Sorted(maps.Keys(defaultConfiguration.Languages)), defaultConfiguration.DefaultLanguage,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	historyExamples, err := g.prompter.Confirm("Learn the commit style from the repository history?", true)
+	if err != nil {
+		return err
+	}
+	input.HistoryExamples = &historyExamples
+	input.TicketPlacement, err = g.prompter.Choose(
+		"Ticket reference placement", []string{vo.TicketPlacementFooter, vo.TicketPlacementPrefix}, vo.TicketPlacementFooter,
+	)
+	return err
+}
@@ -1,14 +1,42 @@
 package command
 
 import (
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/yusadeol/go-commit/internal/adapter/cli/dispatcher"
+
+	"github.com/yusadeol/go-commit/internal/domain/vo"
+	"github.com/yusadeol/go-commit/internal/infra/service/credential"
 )
 
+func newTestInit(t *testing.T, configurationFilePath string) *Init {
+	t.Helper()
+	init := NewInit(configurationFilePath, credential.NewFileKeyring(filepath.Join(t.TempDir(), "commit-credentials.json")))
+	init.interactive = false
+	return init
+}
+
+func newInitInput(options map[string]string) *dispatcher.CommandInput {
+	input := &dispatcher.CommandInput{Options: map[string]dispatcher.OptionInput{}}
+	for name, value := range options {
+		input.Options[name] = dispatcher.OptionInput{Value: value}
+	}
+	return input
+}
+
+func readTestConfiguration(t *testing.T, configurationFilePath string) string {
+	t.Helper()
+	data, err := os.ReadFile(configurationFilePath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return string(data)
+}
+
 func TestInit(t *testing.T) {
 	t.Run("should be able to create the configuration file", func(t *testing.T) {
 		tempDir := t.TempDir()
@@ -17,7 +45,7 @@ func TestInit(t *testing.T) {
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
-		init := NewInit(configurationDirPath)
+		init := newTestInit(t, filepath.Join(configurationDirPath, "commit.json"))
 		result, err := init.Execute(&dispatcher.CommandInput{})
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
@@ -26,6 +54,11 @@ func TestInit(t *testing.T) {
 		if !strings.Contains(result.Message.StripMarkup(), expected) {
 			t.Fatalf("expected message to contain %q, got: %q", expected, result.Message.StripMarkup())
 		}
+		expectedPayload := InitPayload{File: filepath.Join(configurationDirPath, "commit.json"), Created: true}
+		payload, isInitPayload := result.Payload.(*InitPayload)
+		if !isInitPayload || *payload != expectedPayload {
+			t.Fatalf("expected payload %+v, got: %+v", expectedPayload, result.Payload)
+		}
 	})
 
 	t.Run("should return error when the configuration file already exists", func(t *testing.T) {
@@ -44,7 +77,7 @@ func TestInit(t *testing.T) {
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
-		init := NewInit(configurationDirPath)
+		init := newTestInit(t, filepath.Join(configurationDirPath, "commit.json"))
 		result, err := init.Execute(&dispatcher.CommandInput{})
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
@@ -54,4 +87,116 @@ func TestInit(t *testing.T) {
 			t.Fatalf("expected message to contain %q, got: %q", expected, result.Message.StripMarkup())
 		}
 	})
+
+	t.Run("should create missing directories", func(t *testing.T) {
+		configurationFilePath := filepath.Join(t.TempDir(), "home", ".config", "commit.json")
+		result, err := newTestInit(t, configurationFilePath).Execute(&dispatcher.CommandInput{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.ExitCode != vo.ExitCodeSuccess {
+			t.Fatalf("unexpected exit code: %v", result.ExitCode)
+		}
+		fileInfo, err := os.Stat(filepath.Dir(configurationFilePath))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if fileInfo.Mode().Perm() != 0700 {
+			t.Fatalf("expected the directory to be private, got: %v", fileInfo.Mode().Perm())
+		}
+	})
+
+	t.Run("should overwrite the configuration file with force", func(t *testing.T) {
+		configurationFilePath := filepath.Join(t.TempDir(), "commit.json")
+		err := os.WriteFile(configurationFilePath, []byte("{}"), 0644)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		result, err := newTestInit(t, configurationFilePath).Execute(newInitInput(map[string]string{"force": "true"}))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.ExitCode != vo.ExitCodeSuccess {
+			t.Fatalf("unexpected exit code: %v", result.ExitCode)
+		}
+		if !strings.Contains(readTestConfiguration(t, configurationFilePath), `"default_ai_provider": "openai"`) {
+			t.Fatalf("expected the configuration file to be overwritten")
+		}
+	})
+
+	t.Run("should apply the options and keep plaintext keys private", func(t *testing.T) {
+		configurationFilePath := filepath.Join(t.TempDir(), "commit.json")
+		_, err := newTestInit(t, configurationFilePath).Execute(newInitInput(map[string]string{
+			"provider":       "openai",
+			"language":       "pt_BR",
+			"model":          "gpt-4.1-mini",
+			"api-key-source": "plaintext",
+			"api-key":        "sk-secret",
+		}))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		content := readTestConfiguration(t, configurationFilePath)
+		for _, expected := range []string{
+			`"default_language": "pt_BR"`,
+			`"default_model": "gpt-4.1-mini"`,
+			`"api_key": "sk-secret"`,
+		} {
+			if !strings.Contains(content, expected) {
+				t.Fatalf("expected configuration to contain %q, got: %q", expected, content)
+			}
+		}
+		fileInfo, err := os.Stat(configurationFilePath)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if fileInfo.Mode().Perm() != 0600 {
+			t.Fatalf("expected the configuration file to be private, got: %v", fileInfo.Mode().Perm())
+		}
+	})
+
+	t.Run("should store the key in the keyring", func(t *testing.T) {
+		configurationFilePath := filepath.Join(t.TempDir(), "commit.json")
+		keyring := credential.NewFileKeyring(filepath.Join(t.TempDir(), "commit-credentials.json"))
+		init := NewInit(configurationFilePath, keyring)
+		init.interactive = false
+		_, err := init.Execute(newInitInput(map[string]string{"api-key-source": "keyring", "api-key": "sk-secret"}))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		apiKey, err := keyring.Get("openai")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if apiKey != "sk-secret" {
+			t.Fatalf("expected %q, got: %q", "sk-secret", apiKey)
+		}
+		content := readTestConfiguration(t, configurationFilePath)
+		if strings.Contains(content, "sk-secret") || !strings.Contains(content, `"api_key_keyring": true`) {
+			t.Fatalf("expected the key to stay out of the configuration file, got: %q", content)
+		}
+	})
+
+	t.Run("should ask for the settings on a terminal", func(t *testing.T) {
+		configurationFilePath := filepath.Join(t.TempDir(), "commit.json")
+		init := newTestInit(t, configurationFilePath)
+		init.interactive = true
+		init.prompter = NewPrompter(strings.NewReader("\nenv\nMY_OPENAI_KEY\n\nbrazil\npt_BR\nn\nprefix\n"), io.Discard)
+		_, err := init.Execute(newInitInput(map[string]string{}))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		content := readTestConfiguration(t, configurationFilePath)
+		for _, expected := range []string{
+			`"api_key_env": "MY_OPENAI_KEY"`,
+			`"default_model": "gpt-4.1"`,
+			`"default_language": "pt_BR"`,
+			`"placement": "prefix"`,
+			`"enabled": false`,
+		} {
+			if !strings.Contains(content, expected) {
+				t.Fatalf("expected configuration to contain %q, got: %q", expected, content)
+			}
+		}
+	})
 }
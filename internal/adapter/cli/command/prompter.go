@@ -0,0 +1,109 @@
+package command
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// Prompter asks questions on a terminal, offering a default that is used when
+// the answer is left blank.
+type Prompter struct {
+	reader     *bufio.Reader
+	writer     io.Writer
+	readSecret func() (string, error)
+}
+
+func NewPrompter(reader io.Reader, writer io.Writer) *Prompter {
+	prompter := &Prompter{
+		reader: bufio.NewReader(reader),
+		writer: writer,
+	}
+	prompter.readSecret = prompter.readLine
+	if file, isFile := reader.(*os.File); isFile && isTerminal(file) {
+		prompter.readSecret = func() (string, error) {
+			secret, err := term.ReadPassword(int(file.Fd()))
+			return strings.TrimSpace(string(secret)), err
+		}
+	}
+	return prompter
+}
+
+func (p *Prompter) Ask(question string, defaultValue string) (string, error) {
+	if defaultValue != "" {
+		question = fmt.Sprintf("%s [%s]", question, defaultValue)
+	}
+	_, _ = fmt.Fprintf(p.writer, "%s: ", question)
+	answer, err := p.readLine()
+	if err != nil {
+		return "", err
+	}
+	if answer == "" {
+		return defaultValue, nil
+	}
+	return answer, nil
+}
+
+// AskSecret reads an answer without echoing it back to the terminal.
+func (p *Prompter) AskSecret(question string) (string, error) {
+	_, _ = fmt.Fprintf(p.writer, "%s: ", question)
+	answer, err := p.readSecret()
+	_, _ = fmt.Fprintln(p.writer)
+	return answer, err
+}
+
+// Choose asks again until the answer is one of the choices.
+func (p *Prompter) Choose(question string, choices []string, defaultValue string) (string, error) {
+	question = fmt.Sprintf("%s (%s)", question, strings.Join(choices, ", "))
+	for {
+		answer, err := p.Ask(question, defaultValue)
+		if err != nil {
+			return "", err
+		}
+		if slices.Contains(choices, answer) {
+			return answer, nil
+		}
+		_, _ = fmt.Fprintf(p.writer, "%q is not one of: %s\n", answer, strings.Join(choices, ", "))
+	}
+}
+
+func (p *Prompter) Confirm(question string, defaultValue bool) (bool, error) {
+	hint := "y/N"
+	if defaultValue {
+		hint = "Y/n"
+	}
+	for {
+		answer, err := p.Ask(fmt.Sprintf("%s [%s]", question, hint), "")
+		if err != nil {
+			return false, err
+		}
+		switch strings.ToLower(answer) {
+		case "":
+			return defaultValue, nil
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+	}
+}
+
+func (p *Prompter) readLine() (string, error) {
+	line, err := p.reader.ReadString('\n')
+	if err == io.EOF && line != "" {
+		err = nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+func isTerminal(file *os.File) bool {
+	return term.IsTerminal(int(file.Fd()))
+}
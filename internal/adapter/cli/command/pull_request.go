@@ -0,0 +1,122 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/yusadeol/go-commit/internal/adapter/cli/dispatcher"
+
+	"github.com/yusadeol/go-commit/internal/app/usecase"
+	"github.com/yusadeol/go-commit/internal/domain/vo"
+	"github.com/yusadeol/go-commit/internal/infra/service/ai"
+	"github.com/yusadeol/go-commit/internal/infra/service/credential"
+	"github.com/yusadeol/go-commit/internal/infra/service/git"
+)
+
+// PullRequestPayload is the structured output of pr. File is empty when the
+// pull request was not written to a file.
+type PullRequestPayload struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+	File  string `json:"file"`
+}
+
+type PullRequest struct {
+	configuration            *vo.Configuration
+	aiDefaultProviderFactory ai.ProviderFactory
+	credentialResolver       credential.Resolver
+	git                      *git.Git
+}
+
+func NewPullRequest(
+	aiDefaultProviderFactory ai.ProviderFactory,
+	credentialResolver credential.Resolver,
+	git *git.Git,
+) *PullRequest {
+	return &PullRequest{
+		configuration:            &vo.Configuration{},
+		aiDefaultProviderFactory: aiDefaultProviderFactory,
+		credentialResolver:       credentialResolver,
+		git:                      git,
+	}
+}
+
+func (p *PullRequest) SetConfiguration(configuration *vo.Configuration) {
+	p.configuration = configuration
+}
+
+func (p *PullRequest) GetName() string {
+	return "pr"
+}
+
+func (p *PullRequest) GetDescription() string {
+	return "Generate a pull request title and description from the branch commits"
+}
+
+func (p *PullRequest) GetArguments() []dispatcher.Argument {
+	return []dispatcher.Argument{}
+}
+
+func (p *PullRequest) GetOptions() []dispatcher.Option {
+	return append(getAIOptions(p.configuration),
+		dispatcher.Option{
+			Name:        "base",
+			Flag:        "b",
+			Description: "Base branch",
+			Default:     "main",
+		},
+		dispatcher.Option{
+			Name:        "file",
+			Flag:        "f",
+			Description: "File to write the pull request to",
+		},
+	)
+}
+
+func (p *PullRequest) Execute(input *dispatcher.CommandInput) (*dispatcher.Result, error) {
+	result := dispatcher.NewResult()
+	configurationAIProvider, configurationLanguage, err := getAIConfiguration(p.configuration, p.credentialResolver, input)
+	if err != nil {
+		return nil, err
+	}
+	base := input.Options["base"].Value
+	commits, err := p.git.Log(base + "..HEAD")
+	if err != nil {
+		return nil, err
+	}
+	if len(commits) == 0 {
+		return nil, fmt.Errorf("no commits found between %s and HEAD", base)
+	}
+	diff, err := p.git.Diff(base + "...HEAD")
+	if err != nil {
+		return nil, err
+	}
+	generatePullRequest := usecase.NewGeneratePullRequest()
+	output, err := generatePullRequest.Execute(&usecase.GeneratePullRequestInput{
+		AIDefaultProviderFactory: p.aiDefaultProviderFactory,
+		AIProvider:               configurationAIProvider,
+		Language:                 configurationLanguage,
+		Commits:                  commits,
+		Diff:                     diff,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if output.Title == "" {
+		return nil, errors.New("AI provider returned an empty pull request")
+	}
+	pullRequest := output.Title + "\n\n" + output.Body
+	filePath := input.Options["file"].Value
+	result.Payload = &PullRequestPayload{Title: output.Title, Body: output.Body, File: filePath}
+	if filePath == "" {
+		result.Message = vo.NewMarkupText(pullRequest)
+		return result, nil
+	}
+	err = os.WriteFile(filePath, []byte(pullRequest+"\n"), 0644)
+	if err != nil {
+		return nil, err
+	}
+	result.Message = vo.NewMarkupText(fmt.Sprintf("<success>pull request written to %s</success>", filePath))
+	return result, nil
+}
@@ -0,0 +1,75 @@
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/yusadeol/go-commit/internal/adapter/cli/dispatcher"
+
+	"github.com/yusadeol/go-commit/internal/domain/vo"
+)
+
+func TestPullRequest(t *testing.T) {
+	mockConfiguration := vo.Configuration{
+		AIProviders: map[string]vo.AIProvider{
+			"mock": {ID: "mock", APIKey: "fake-api-key", DefaultModel: "mock-model"},
+		},
+		Languages: map[string]vo.Language{
+			"en_US": {ID: "en_US", DisplayName: "English (US)"},
+		},
+	}
+
+	t.Run("should be able to write the pull request to a file", func(t *testing.T) {
+		repositoryDirPath, repository := newTestRepository(t)
+		commitTestFile(t, repositoryDirPath, repository, "a.txt", "chore: initial commit")
+		_, err := repository.Run("switch", "-c", "feature")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		commitTestFile(t, repositoryDirPath, repository, "b.txt", "feat: rename function")
+		filePath := filepath.Join(t.TempDir(), "pr.md")
+		pullRequest := NewPullRequest(&MockDefaultProviderFactory{}, newTestCredentialResolver(t), repository)
+		pullRequest.SetConfiguration(&mockConfiguration)
+		result, err := pullRequest.Execute(&dispatcher.CommandInput{
+			Options: map[string]dispatcher.OptionInput{
+				"provider": {Value: "mock"},
+				"language": {Value: "en_US"},
+				"base":     {Value: "main"},
+				"file":     {Value: filePath},
+			},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.ExitCode != vo.ExitCodeSuccess {
+			t.Fatalf("unexpected exit code: %v", result.ExitCode)
+		}
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := "feat: rename function and update greeting message"
+		if !strings.HasPrefix(string(data), expected) {
+			t.Fatalf("expected pull request to start with %q, got: %q", expected, string(data))
+		}
+	})
+
+	t.Run("should return error when the branch has no commits", func(t *testing.T) {
+		repositoryDirPath, repository := newTestRepository(t)
+		commitTestFile(t, repositoryDirPath, repository, "a.txt", "chore: initial commit")
+		pullRequest := NewPullRequest(&MockDefaultProviderFactory{}, newTestCredentialResolver(t), repository)
+		pullRequest.SetConfiguration(&mockConfiguration)
+		_, err := pullRequest.Execute(&dispatcher.CommandInput{
+			Options: map[string]dispatcher.OptionInput{
+				"provider": {Value: "mock"},
+				"language": {Value: "en_US"},
+				"base":     {Value: "main"},
+			},
+		})
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
@@ -0,0 +1,139 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yusadeol/go-commit/internal/adapter/cli/dispatcher"
+
+	"github.com/yusadeol/go-commit/internal/app/usecase"
+	"github.com/yusadeol/go-commit/internal/domain/vo"
+	"github.com/yusadeol/go-commit/internal/infra/service/ai"
+	"github.com/yusadeol/go-commit/internal/infra/service/credential"
+	"github.com/yusadeol/go-commit/internal/infra/service/git"
+)
+
+// SplitPayload is the structured output of split, whose groups are only
+// committed without --dry-run.
+type SplitPayload struct {
+	Groups    []SplitGroupPayload `json:"groups"`
+	Committed bool                `json:"committed"`
+}
+
+type SplitGroupPayload struct {
+	Files   []string `json:"files"`
+	Message string   `json:"message"`
+}
+
+type Split struct {
+	configuration            *vo.Configuration
+	aiDefaultProviderFactory ai.ProviderFactory
+	credentialResolver       credential.Resolver
+	git                      *git.Git
+}
+
+func NewSplit(
+	aiDefaultProviderFactory ai.ProviderFactory,
+	credentialResolver credential.Resolver,
+	git *git.Git,
+) *Split {
+	return &Split{
+		configuration:            &vo.Configuration{},
+		aiDefaultProviderFactory: aiDefaultProviderFactory,
+		credentialResolver:       credentialResolver,
+		git:                      git,
+	}
+}
+
+func (s *Split) SetConfiguration(configuration *vo.Configuration) {
+	s.configuration = configuration
+}
+
+func (s *Split) GetName() string {
+	return "split"
+}
+
+func (s *Split) GetDescription() string {
+	return "Split the staged changes into several commits"
+}
+
+func (s *Split) GetArguments() []dispatcher.Argument {
+	return []dispatcher.Argument{}
+}
+
+func (s *Split) GetOptions() []dispatcher.Option {
+	return append(getAIOptions(s.configuration),
+		dispatcher.Option{
+			Name:        "dry-run",
+			Flag:        "d",
+			Description: "Only show the plan",
+			Type:        dispatcher.OptionTypeBool,
+			Default:     "false",
+		},
+		dispatcher.Option{
+			Name:        "examples",
+			Flag:        "e",
+			Description: "Use recent commits as style examples",
+			Type:        dispatcher.OptionTypeBool,
+			Default:     "true",
+		},
+	)
+}
+
+func (s *Split) Execute(input *dispatcher.CommandInput) (*dispatcher.Result, error) {
+	result := dispatcher.NewResult()
+	configurationAIProvider, configurationLanguage, err := getAIConfiguration(s.configuration, s.credentialResolver, input)
+	if err != nil {
+		return nil, err
+	}
+	planCommitSplitInput := &usecase.PlanCommitSplitInput{
+		AIDefaultProviderFactory: s.aiDefaultProviderFactory,
+		AIProvider:               configurationAIProvider,
+		Language:                 configurationLanguage,
+		Git:                      s.git,
+		Prompt:                   s.configuration.Prompt,
+		Types:                    s.configuration.Types,
+		Scopes:                   s.configuration.Scopes,
+	}
+	if s.configuration.Ticket.Pattern != "" {
+		// A branch without commits has no name to read the ticket from yet,
+		// so the reference is just skipped.
+		branch, err := s.git.CurrentBranch()
+		if err == nil {
+			planCommitSplitInput.Ticket = &s.configuration.Ticket
+			planCommitSplitInput.Branch = branch
+		}
+	}
+	if s.configuration.HistoryExamples.Enabled && input.Options["examples"].Bool() {
+		planCommitSplitInput.Examples = getStyleExamples(s.git, s.configuration.HistoryExamples.Count)
+	}
+	planCommitSplit := usecase.NewPlanCommitSplit()
+	output, err := planCommitSplit.Execute(planCommitSplitInput)
+	if err != nil {
+		return nil, err
+	}
+	payload := &SplitPayload{Groups: make([]SplitGroupPayload, 0, len(output.Groups))}
+	result.Payload = payload
+	message := []string{fmt.Sprintf("<info>%d commits planned:</info>", len(output.Groups))}
+	for index, group := range output.Groups {
+		payload.Groups = append(payload.Groups, SplitGroupPayload{Files: group.Files, Message: group.Commit})
+		message = append(message,
+			"",
+			fmt.Sprintf("<info>%d. %s</info>", index+1, strings.Join(group.Files, ", ")),
+			fmt.Sprintf("<comment>%s</comment>", group.Commit),
+		)
+	}
+	if input.Options["dry-run"].Bool() {
+		result.Message = vo.NewColoredMultilineText(message)
+		return result, nil
+	}
+	applyCommitSplit := usecase.NewApplyCommitSplit()
+	err = applyCommitSplit.Execute(&usecase.ApplyCommitSplitInput{Git: s.git, Groups: output.Groups})
+	if err != nil {
+		return nil, err
+	}
+	payload.Committed = true
+	message = append(message, "", "<success>Commits applied successfully!</success>")
+	result.Message = vo.NewColoredMultilineText(message)
+	return result, nil
+}
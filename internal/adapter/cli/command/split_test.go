@@ -0,0 +1,231 @@
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/yusadeol/go-commit/internal/adapter/cli/dispatcher"
+
+	"github.com/yusadeol/go-commit/internal/domain/vo"
+	"github.com/yusadeol/go-commit/internal/infra/service/ai"
+	"github.com/yusadeol/go-commit/internal/infra/service/git"
+)
+
+type MockSplitProvider struct {
+	groups string
+}
+
+func (m *MockSplitProvider) Ask(input *ai.ProviderInput) (*ai.ProviderOutput, error) {
+	if strings.Contains(input.Instructions, "JSON array") {
+		return &ai.ProviderOutput{Status: "success", Text: m.groups}, nil
+	}
+	return &ai.ProviderOutput{Status: "success", Text: "feat: " + strings.Fields(input.Input)[2]}, nil
+}
+
+type MockSplitProviderFactory struct {
+	groups string
+}
+
+func (m *MockSplitProviderFactory) Create(id string, apiKey string) (ai.Provider, error) {
+	return &MockSplitProvider{groups: m.groups}, nil
+}
+
+func TestSplit(t *testing.T) {
+	mockConfiguration := vo.Configuration{
+		AIProviders: map[string]vo.AIProvider{
+			"mock": {ID: "mock", APIKey: "fake-api-key", DefaultModel: "mock-model"},
+		},
+		Languages: map[string]vo.Language{
+			"en_US": {ID: "en_US", DisplayName: "English (US)"},
+		},
+	}
+	stageSplitFiles := func(t *testing.T, repositoryDirPath string, repository *git.Git) {
+		for _, fileName := range []string{"a.txt", "b.txt", "c.txt"} {
+			err := os.WriteFile(filepath.Join(repositoryDirPath, fileName), []byte(fileName), 0644)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+		_, err := repository.Run("add", ".")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	newSplitRepository := func(t *testing.T) (string, *git.Git) {
+		repositoryDirPath, repository := newTestRepository(t)
+		commitTestFile(t, repositoryDirPath, repository, "initial.txt", "chore: initial commit")
+		stageSplitFiles(t, repositoryDirPath, repository)
+		return repositoryDirPath, repository
+	}
+	splitInput := &dispatcher.CommandInput{
+		Options: map[string]dispatcher.OptionInput{
+			"provider": {Value: "mock"},
+			"language": {Value: "en_US"},
+			"dry-run":  {Value: "false"},
+		},
+	}
+
+	t.Run("should be able to split the staged changes into commits", func(t *testing.T) {
+		_, repository := newSplitRepository(t)
+		split := NewSplit(&MockSplitProviderFactory{groups: `[["a.txt", "c.txt"]]`}, newTestCredentialResolver(t), repository)
+		split.SetConfiguration(&mockConfiguration)
+		_, err := split.Execute(splitInput)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		commits, err := repository.Log("HEAD~2..HEAD")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(commits) != 2 {
+			t.Fatalf("expected 2 commits, got: %d", len(commits))
+		}
+		for index, expected := range []string{"b.txt", "a.txt"} {
+			files, err := repository.Run("show", "--name-only", "--format=", commits[index].Hash)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !strings.HasPrefix(files, expected) {
+				t.Fatalf("expected commit %d to start with %q, got: %q", index, expected, files)
+			}
+		}
+	})
+
+	t.Run("should add the ticket of the branch to every commit", func(t *testing.T) {
+		_, repository := newSplitRepository(t)
+		err := repository.SwitchCreate("feature/PROJ-1234-greeting")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		configuration := mockConfiguration
+		configuration.Ticket = vo.Ticket{Pattern: `[A-Z][A-Z0-9]+-[0-9]+`, Placement: vo.TicketPlacementFooter, FooterToken: "Refs"}
+		split := NewSplit(&MockSplitProviderFactory{groups: `[["a.txt"], ["b.txt", "c.txt"]]`}, newTestCredentialResolver(t), repository)
+		split.SetConfiguration(&configuration)
+		_, err = split.Execute(splitInput)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		commits, err := repository.Log("HEAD~2..HEAD")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, commit := range commits {
+			if !strings.HasSuffix(commit.Message, "Refs: PROJ-1234") {
+				t.Fatalf("expected the commit to end with the ticket footer, got: %q", commit.Message)
+			}
+		}
+	})
+
+	t.Run("should be able to split in a repository without commits", func(t *testing.T) {
+		repositoryDirPath, repository := newTestRepository(t)
+		stageSplitFiles(t, repositoryDirPath, repository)
+		split := NewSplit(&MockSplitProviderFactory{groups: `[["a.txt"], ["b.txt", "c.txt"]]`}, newTestCredentialResolver(t), repository)
+		split.SetConfiguration(&mockConfiguration)
+		_, err := split.Execute(splitInput)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		commits, err := repository.Log("HEAD")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(commits) != 2 {
+			t.Fatalf("expected 2 commits, got: %d", len(commits))
+		}
+		files, err := repository.Run("show", "--name-only", "--format=", commits[1].Hash)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if strings.TrimSpace(files) != "a.txt" {
+			t.Fatalf("expected the first commit to only hold a.txt, got: %q", files)
+		}
+	})
+
+	t.Run("should roll back to no commits when a commit fails in a new repository", func(t *testing.T) {
+		repositoryDirPath, repository := newTestRepository(t)
+		stageSplitFiles(t, repositoryDirPath, repository)
+		hookPath := filepath.Join(repositoryDirPath, ".git", "hooks", "commit-msg")
+		err := os.WriteFile(hookPath, []byte("#!/bin/sh\ngrep -q c.txt \"$1\" && exit 1\nexit 0\n"), 0755)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		split := NewSplit(&MockSplitProviderFactory{groups: `[["a.txt"], ["b.txt"], ["c.txt"]]`}, newTestCredentialResolver(t), repository)
+		split.SetConfiguration(&mockConfiguration)
+		_, err = split.Execute(splitInput)
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		head, err := repository.HeadCommit()
+		if err != nil || head != "" {
+			t.Fatalf("expected no commits, got: %q, %v", head, err)
+		}
+		stagedFiles, err := repository.StagedFiles()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(stagedFiles) != 3 {
+			t.Fatalf("expected 3 staged files, got: %v", stagedFiles)
+		}
+	})
+
+	t.Run("should not commit anything on dry run", func(t *testing.T) {
+		_, repository := newSplitRepository(t)
+		split := NewSplit(&MockSplitProviderFactory{groups: `[["a.txt"], ["b.txt", "c.txt"]]`}, newTestCredentialResolver(t), repository)
+		split.SetConfiguration(&mockConfiguration)
+		result, err := split.Execute(&dispatcher.CommandInput{
+			Options: map[string]dispatcher.OptionInput{
+				"provider": {Value: "mock"},
+				"language": {Value: "en_US"},
+				"dry-run":  {Value: "true"},
+			},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.Contains(result.Message.StripMarkup(), "2 commits planned") {
+			t.Fatalf("expected the plan to be shown, got: %q", result.Message.StripMarkup())
+		}
+		stagedFiles, err := repository.StagedFiles()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(stagedFiles) != 3 {
+			t.Fatalf("expected 3 staged files, got: %v", stagedFiles)
+		}
+	})
+
+	t.Run("should roll back when a commit fails", func(t *testing.T) {
+		repositoryDirPath, repository := newSplitRepository(t)
+		hookPath := filepath.Join(repositoryDirPath, ".git", "hooks", "commit-msg")
+		err := os.WriteFile(hookPath, []byte("#!/bin/sh\ngrep -q c.txt \"$1\" && exit 1\nexit 0\n"), 0755)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		head, err := repository.RevParse("HEAD")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		split := NewSplit(&MockSplitProviderFactory{groups: `[["a.txt"], ["b.txt"], ["c.txt"]]`}, newTestCredentialResolver(t), repository)
+		split.SetConfiguration(&mockConfiguration)
+		_, err = split.Execute(splitInput)
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		currentHead, err := repository.RevParse("HEAD")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if currentHead != head {
+			t.Fatalf("expected HEAD to be restored to %s, got: %s", head, currentHead)
+		}
+		stagedFiles, err := repository.StagedFiles()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(stagedFiles) != 3 {
+			t.Fatalf("expected 3 staged files, got: %v", stagedFiles)
+		}
+	})
+}
@@ -20,6 +20,10 @@ func (g *Version) GetName() string {
 	return "version"
 }
 
+func (g *Version) GetDescription() string {
+	return "Show the version"
+}
+
 func (g *Version) GetArguments() []dispatcher.Argument {
 	return []dispatcher.Argument{}
 }
@@ -31,5 +35,6 @@ func (g *Version) GetOptions() []dispatcher.Option {
 func (g *Version) Execute(input *dispatcher.CommandInput) (*dispatcher.Result, error) {
 	result := dispatcher.NewResult()
 	result.Message = vo.NewMarkupText(fmt.Sprintf("<success>%s</success>", g.version))
+	result.Payload = map[string]string{"version": g.version}
 	return result, nil
 }
@@ -1,23 +1,87 @@
 package dispatcher
 
+import (
+	"strconv"
+	"time"
+
+	"github.com/yusadeol/go-commit/internal/domain/vo"
+)
+
 type Command interface {
 	GetName() string
+	GetDescription() string
 	GetArguments() []Argument
 	GetOptions() []Option
 	Execute(input *CommandInput) (*Result, error)
 }
 
+// ConfigurableCommand is implemented by commands that need the configuration.
+// It is only loaded when one of them is dispatched, before GetOptions.
+type ConfigurableCommand interface {
+	Command
+	SetConfiguration(configuration *vo.Configuration)
+}
+
+// OptionalConfigurationCommand is implemented by configurable commands that
+// also run without the configuration, such as changelog, which only needs it
+// to polish the entries. A configuration that cannot be loaded is only
+// reported when RequiresConfiguration returns true for the parsed input.
+type OptionalConfigurationCommand interface {
+	ConfigurableCommand
+	RequiresConfiguration(input *CommandInput) bool
+}
+
+// AliasedCommand is implemented by commands that can also be called by
+// shorter names, such as g for generate.
+type AliasedCommand interface {
+	Command
+	GetAliases() []string
+}
+
+// HiddenCommand is implemented by commands left out of the usage and the
+// completions, such as the ones run by the completion scripts.
+type HiddenCommand interface {
+	Command
+	IsHidden() bool
+}
+
+// rawCommand is implemented by commands that parse their own arguments, such
+// as command groups, which pass them on to their subcommands.
+type rawCommand interface {
+	Command
+	dispatch(args []string) (*Result, error)
+}
+
+type ConfigurationLoader func() (*vo.Configuration, error)
+
 type Argument struct {
 	Name        string
 	Description string
 	Required    bool
 }
 
+type OptionType int
+
+const (
+	OptionTypeString OptionType = iota
+	OptionTypeBool
+	OptionTypeInt
+	OptionTypeDuration
+)
+
+// Option is given as --name=value, --name value, -f value or -fvalue. Bool
+// options take no value and are negated with --no-name. Repeated options
+// collect every value given, while the last one wins for the others, so an
+// alias such as generate --no-commit can be overridden with --commit. Completions are offered by the shell completion
+// along with the allowed values, without restricting the value.
 type Option struct {
 	Name          string
 	Flag          string
 	Description   string
+	Type          OptionType
+	Repeated      bool
 	AllowedValues []string
+	Completions   []string
 	Default       string
 }
 
@@ -32,8 +96,25 @@ type ArgumentInput struct {
 }
 
 type OptionInput struct {
-	Value string
-	Meta  Option
+	Value  string
+	Values []string
+	Meta   Option
+}
+
+// Bool, Int and Duration convert values already validated by the dispatcher.
+func (o OptionInput) Bool() bool {
+	value, _ := strconv.ParseBool(o.Value)
+	return value
+}
+
+func (o OptionInput) Int() int {
+	value, _ := strconv.Atoi(o.Value)
+	return value
+}
+
+func (o OptionInput) Duration() time.Duration {
+	value, _ := time.ParseDuration(o.Value)
+	return value
 }
 
 func NewCommandInput(arguments map[string]ArgumentInput, options map[string]OptionInput) *CommandInput {
@@ -1,172 +1,178 @@
 package dispatcher
 
 import (
+	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/yusadeol/go-commit/internal/domain/vo"
 )
 
+// CommandDispatcher runs the registered commands by name or alias. The
+// dispatchers of command groups have a parent, which provides the
+// configuration loader and the global options.
 type CommandDispatcher struct {
-	commands map[string]Command
+	name                string
+	parent              *CommandDispatcher
+	commands            map[string]Command
+	aliases             map[string]string
+	configurationLoader ConfigurationLoader
+	globalOptions       []Option
 }
 
 func NewCommandDispatcher() *CommandDispatcher {
-	return &CommandDispatcher{commands: make(map[string]Command)}
+	commandDispatcher := newCommandDispatcher()
+	commandDispatcher.Register(&helpCommand{dispatcher: commandDispatcher})
+	return commandDispatcher
+}
+
+func newCommandDispatcher() *CommandDispatcher {
+	return &CommandDispatcher{commands: make(map[string]Command), aliases: make(map[string]string)}
+}
+
+func (c *CommandDispatcher) SetConfigurationLoader(configurationLoader ConfigurationLoader) {
+	c.configurationLoader = configurationLoader
+}
+
+// SetGlobalOptions sets the options parsed before dispatching, so they are
+// listed in the usage.
+func (c *CommandDispatcher) SetGlobalOptions(globalOptions []Option) {
+	c.globalOptions = globalOptions
 }
 
 func (c *CommandDispatcher) Register(command Command) {
 	c.commands[command.GetName()] = command
+	if aliasedCommand, isAliased := command.(AliasedCommand); isAliased {
+		for _, alias := range aliasedCommand.GetAliases() {
+			c.aliases[alias] = command.GetName()
+		}
+	}
+	if commandGroup, isGroup := command.(*CommandGroup); isGroup {
+		commandGroup.dispatcher.parent = c
+	}
 }
 
 func (c *CommandDispatcher) Dispatch(calledCommandName string, args []string) (*Result, error) {
-	command, exists := c.commands[calledCommandName]
+	command, exists := c.find(calledCommandName)
 	if !exists {
-		return &Result{
-			ExitCode: vo.ExitCodeCommandNotFound,
-			Message:  vo.NewMarkupText("<error>command not found</error>"),
-		}, nil
+		return c.commandNotFound(calledCommandName), nil
+	}
+	if rawCommand, isRaw := command.(rawCommand); isRaw {
+		return rawCommand.dispatch(args)
+	}
+	failedResult := c.configure(command)
+	commandInput, err := parseArgs(command.GetArguments(), command.GetOptions(), args)
+	if errors.Is(err, errHelpRequested) {
+		result := NewResult()
+		result.Message = c.commandUsage(command)
+		return result, nil
+	}
+	if failedResult != nil {
+		optionalConfigurationCommand, isOptional := command.(OptionalConfigurationCommand)
+		if !isOptional || (err == nil && optionalConfigurationCommand.RequiresConfiguration(commandInput)) {
+			return failedResult, nil
+		}
 	}
-	commandInput, err := c.parseCommandInput(command.GetArguments(), c.standardizeOptions(command.GetOptions()), args)
 	if err != nil {
+		lines := []string{fmt.Sprintf("<error>%s</error>", err.Error())}
+		var usageErr *usageError
+		if errors.As(err, &usageErr) && usageErr.suggestion != "" {
+			lines = append(lines, fmt.Sprintf("<info>Did you mean %q?</info>", usageErr.suggestion))
+		}
 		return &Result{
 			ExitCode: vo.ExitCodeInvalidUsage,
-			Message:  vo.NewMarkupText(fmt.Sprintf("<error>%s</error>", err.Error())),
+			Message:  vo.NewColoredMultilineText(lines),
 		}, nil
 	}
 	return command.Execute(commandInput)
 }
 
-func (c *CommandDispatcher) standardizeOptions(options []Option) map[string]Option {
-	standardizedOptions := make(map[string]Option, len(options))
-	for _, option := range options {
-		standardizedOptions[option.Name] = option
+// find returns the command registered under name or under an alias.
+func (c *CommandDispatcher) find(name string) (Command, bool) {
+	if commandName, isAlias := c.aliases[name]; isAlias {
+		name = commandName
 	}
-	return standardizedOptions
+	command, exists := c.commands[name]
+	return command, exists
 }
 
-func (c *CommandDispatcher) parseCommandInput(arguments []Argument, options map[string]Option, args []string) (*CommandInput, error) {
-	argumentInputs, err := c.parseArguments(arguments, args)
-	if err != nil {
-		return nil, err
+func (c *CommandDispatcher) commandNotFound(commandName string) *Result {
+	lines := []string{fmt.Sprintf("<error>command not found: %s</error>", strings.Join(append(c.commandPath(), commandName), " "))}
+	candidates := append(c.getVisibleCommandNames(), slices.Sorted(maps.Keys(c.aliases))...)
+	if suggestion := vo.Suggest(commandName, candidates); suggestion != "" {
+		lines = append(lines, fmt.Sprintf("<info>Did you mean %q?</info>", suggestion))
+	} else {
+		helpCommandLine := strings.Join(append([]string{programName, helpCommandName}, c.commandPath()...), " ")
+		lines = append(lines, fmt.Sprintf("<info>Run \"%s\" to list the available commands.</info>", helpCommandLine))
 	}
-	optionInputs, err := c.parseOptions(options, args)
-	if err != nil {
-		return nil, err
+	return &Result{
+		ExitCode: vo.ExitCodeCommandNotFound,
+		Message:  vo.NewColoredMultilineText(lines),
 	}
-	return NewCommandInput(argumentInputs, optionInputs), nil
 }
 
-func (c *CommandDispatcher) parseArguments(arguments []Argument, args []string) (map[string]ArgumentInput, error) {
-	argumentsFromArgs := c.extractArgumentsFromArgs(args)
-	argumentInputs := make(map[string]ArgumentInput, len(argumentsFromArgs))
-	for index, argument := range arguments {
-		if index < len(argumentsFromArgs) {
-			argumentInputs[argument.Name] = ArgumentInput{Value: argumentsFromArgs[index], Meta: argument}
+// getVisibleCommandNames returns the sorted names of the commands that are
+// not hidden.
+func (c *CommandDispatcher) getVisibleCommandNames() []string {
+	var names []string
+	for _, name := range slices.Sorted(maps.Keys(c.commands)) {
+		if hiddenCommand, isHidden := c.commands[name].(HiddenCommand); isHidden && hiddenCommand.IsHidden() {
 			continue
 		}
-		if argument.Required {
-			return nil, fmt.Errorf("missing required argument: %s", argument.Name)
-		}
+		names = append(names, name)
 	}
-	return argumentInputs, nil
+	return names
 }
 
-func (c *CommandDispatcher) extractArgumentsFromArgs(args []string) []string {
-	var argumentsFromArgs []string
-	var previousArgIsOption bool
-	for _, arg := range args {
-		if previousArgIsOption {
-			previousArgIsOption = false
-			continue
-		}
-		if strings.HasPrefix(arg, "-") {
-			previousArgIsOption = true
-			continue
-		}
-		argumentsFromArgs = append(argumentsFromArgs, arg)
+// commandPath returns the names of the groups leading to this dispatcher,
+// which is empty for the top level one.
+func (c *CommandDispatcher) commandPath() []string {
+	if c.parent == nil {
+		return []string{}
 	}
-	return argumentsFromArgs
+	return append(c.parent.commandPath(), c.name)
 }
 
-func (c *CommandDispatcher) parseOptions(options map[string]Option, args []string) (map[string]OptionInput, error) {
-	optionsFromArgs := c.extractOptionsFromArgs(args)
-	standardizedOptionsFromArgs := c.standardizeOptionsFromArgs(optionsFromArgs)
-	optionInputs := map[string]OptionInput{}
-	recognizedOptions := map[string]bool{}
-	for _, option := range options {
-		matchedOptionIdentifier := option.Name
-		standardizedOptionValue, standardizedOptionExists := standardizedOptionsFromArgs[matchedOptionIdentifier]
-		if !standardizedOptionExists {
-			matchedOptionIdentifier = option.Flag
-			standardizedOptionValue, standardizedOptionExists = standardizedOptionsFromArgs[matchedOptionIdentifier]
-			if !standardizedOptionExists {
-				optionInputs[option.Name] = OptionInput{Meta: option}
-				continue
-			}
-		}
-		recognizedOptions[matchedOptionIdentifier] = true
-		valueIsRecognized := false
-		if len(option.AllowedValues) > 0 {
-			for _, allowedValue := range option.AllowedValues {
-				if allowedValue == standardizedOptionValue {
-					valueIsRecognized = true
-				}
-			}
-			if !valueIsRecognized {
-				return nil, fmt.Errorf(
-					"invalid value for option %q: %q. Allowed values are: %s",
-					option.Name, standardizedOptionValue, strings.Join(option.AllowedValues, ", "),
-				)
-			}
-		}
-		optionInputs[option.Name] = OptionInput{Value: standardizedOptionValue, Meta: option}
-	}
-	for optionName, optionInput := range optionInputs {
-		if optionInput.Value == "" {
-			optionInput.Value = optionInput.Meta.Default
-		}
-		optionInputs[optionName] = optionInput
+func (c *CommandDispatcher) getConfigurationLoader() ConfigurationLoader {
+	if c.configurationLoader == nil && c.parent != nil {
+		return c.parent.getConfigurationLoader()
 	}
-	for standardizedOptionName := range standardizedOptionsFromArgs {
-		if !recognizedOptions[standardizedOptionName] {
-			return nil, fmt.Errorf("unknown option: %s", standardizedOptionName)
-		}
-	}
-	return optionInputs, nil
+	return c.configurationLoader
 }
 
-func (c *CommandDispatcher) extractOptionsFromArgs(args []string) []string {
-	var optionsFromArgs []string
-	var previousArg string
-	for _, arg := range args {
-		if previousArg != "" {
-			optionsFromArgs = append(optionsFromArgs, previousArg+"="+arg)
-			previousArg = ""
-			continue
-		}
-		if !strings.HasPrefix(arg, "-") {
-			continue
-		}
-		if !strings.Contains(arg, "=") {
-			previousArg = arg
-			continue
-		}
-		optionsFromArgs = append(optionsFromArgs, arg)
+func (c *CommandDispatcher) getGlobalOptions() []Option {
+	if c.globalOptions == nil && c.parent != nil {
+		return c.parent.getGlobalOptions()
 	}
-	return optionsFromArgs
+	return c.globalOptions
 }
 
-func (c *CommandDispatcher) standardizeOptionsFromArgs(optionsFromArgs []string) map[string]string {
-	standardizedOptionsFromArgs := map[string]string{}
-	for _, option := range optionsFromArgs {
-		parts := strings.SplitN(option, "=", 2)
-		if len(parts) != 2 {
-			continue
+// configure loads the configuration for the commands that need it and
+// returns the result to exit with when it cannot be loaded.
+func (c *CommandDispatcher) configure(command Command) *Result {
+	configurableCommand, isConfigurable := command.(ConfigurableCommand)
+	configurationLoader := c.getConfigurationLoader()
+	if !isConfigurable || configurationLoader == nil {
+		return nil
+	}
+	configuration, err := configurationLoader()
+	if errors.Is(err, vo.ErrConfigurationNotFound) {
+		return &Result{
+			ExitCode: vo.ExitCodeConfiguration,
+			Message: vo.NewColoredMultilineText([]string{
+				fmt.Sprintf("<error>%s</error>", err.Error()),
+				"<info>Run \"commit init\" to create it.</info>",
+			}),
+		}
+	}
+	if err != nil {
+		return &Result{
+			ExitCode: vo.ExitCodeConfiguration,
+			Message:  vo.NewMarkupText(fmt.Sprintf("<error>%s</error>", err.Error())),
 		}
-		optionName := strings.TrimLeft(parts[0], "-")
-		optionValue := parts[1]
-		standardizedOptionsFromArgs[optionName] = optionValue
 	}
-	return standardizedOptionsFromArgs
+	configurableCommand.SetConfiguration(configuration)
+	return nil
 }
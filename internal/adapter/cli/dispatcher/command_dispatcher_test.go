@@ -1,6 +1,8 @@
 package dispatcher
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/yusadeol/go-commit/internal/domain/vo"
@@ -43,6 +45,29 @@ func (m *mockCommand) GetName() string {
 	return "mock"
 }
 
+func (m *mockCommand) GetDescription() string {
+	return "My mock command"
+}
+
+type mockConfigurableCommand struct {
+	mockCommand
+	configuration *vo.Configuration
+}
+
+func (m *mockConfigurableCommand) SetConfiguration(configuration *vo.Configuration) {
+	m.configuration = configuration
+}
+
+// mockOptionalConfigurationCommand only requires the configuration when the
+// first option is not the default one.
+type mockOptionalConfigurationCommand struct {
+	mockConfigurableCommand
+}
+
+func (m *mockOptionalConfigurationCommand) RequiresConfiguration(input *CommandInput) bool {
+	return input.Options["first"].Value != "default-value"
+}
+
 func TestCommandDispatcher(t *testing.T) {
 	t.Run("dispatches a command with required argument and option", func(t *testing.T) {
 		args := []string{"argument-value", "--first", "option-value"}
@@ -144,4 +169,196 @@ func TestCommandDispatcher(t *testing.T) {
 			t.Fatalf("expected ExitCodeInvalidUsage, got: %v", output.ExitCode)
 		}
 	})
+	t.Run("loads the configuration only for configurable commands", func(t *testing.T) {
+		loaderCalls := 0
+		dispatcher := NewCommandDispatcher()
+		dispatcher.SetConfigurationLoader(func() (*vo.Configuration, error) {
+			loaderCalls++
+			return &vo.Configuration{DefaultLanguage: "en_US"}, nil
+		})
+		command := newMockCommand()
+		dispatcher.Register(command)
+		_, err := dispatcher.Dispatch("mock", []string{"argument-value"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if loaderCalls != 0 {
+			t.Fatalf("expected the configuration not to be loaded, got %d calls", loaderCalls)
+		}
+		configurableCommand := &mockConfigurableCommand{}
+		dispatcher.Register(configurableCommand)
+		_, err = dispatcher.Dispatch("mock", []string{"argument-value"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if loaderCalls != 1 || configurableCommand.configuration == nil {
+			t.Fatalf("expected the configuration to be loaded once, got %d calls", loaderCalls)
+		}
+	})
+
+	t.Run("returns a hint when the configuration file is missing", func(t *testing.T) {
+		dispatcher := NewCommandDispatcher()
+		dispatcher.SetConfigurationLoader(func() (*vo.Configuration, error) {
+			return nil, vo.ErrConfigurationNotFound
+		})
+		configurableCommand := &mockConfigurableCommand{}
+		dispatcher.Register(configurableCommand)
+		output, err := dispatcher.Dispatch("mock", []string{"argument-value"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if output.ExitCode != vo.ExitCodeConfiguration {
+			t.Fatalf("expected ExitCodeConfiguration, got: %v", output.ExitCode)
+		}
+		if !strings.Contains(output.Message.StripMarkup(), "commit init") {
+			t.Errorf("expected message to mention commit init, got: %q", output.Message.StripMarkup())
+		}
+		if configurableCommand.executed {
+			t.Fatal("expected command not to be executed")
+		}
+	})
+
+	t.Run("reports a missing configuration only when the input requires it", func(t *testing.T) {
+		dispatcher := NewCommandDispatcher()
+		dispatcher.SetConfigurationLoader(func() (*vo.Configuration, error) {
+			return nil, vo.ErrConfigurationNotFound
+		})
+		command := &mockOptionalConfigurationCommand{}
+		dispatcher.Register(command)
+		output, err := dispatcher.Dispatch("mock", []string{"argument-value"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if output.ExitCode != vo.ExitCodeSuccess || !command.executed {
+			t.Fatalf("expected the command to run without the configuration, got: %v", output.ExitCode)
+		}
+		command.executed = false
+		output, err = dispatcher.Dispatch("mock", []string{"argument-value", "--first=option-value"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if output.ExitCode != vo.ExitCodeConfiguration || command.executed {
+			t.Fatalf("expected ExitCodeConfiguration without running the command, got: %v", output.ExitCode)
+		}
+	})
+
+	t.Run("returns error when the configuration is invalid", func(t *testing.T) {
+		dispatcher := NewCommandDispatcher()
+		dispatcher.SetConfigurationLoader(func() (*vo.Configuration, error) {
+			return nil, errors.New("invalid character")
+		})
+		dispatcher.Register(&mockConfigurableCommand{})
+		output, err := dispatcher.Dispatch("mock", []string{"argument-value"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if output.ExitCode != vo.ExitCodeConfiguration {
+			t.Fatalf("expected ExitCodeConfiguration, got: %v", output.ExitCode)
+		}
+	})
+}
+
+func TestCommandDispatcherSuggestions(t *testing.T) {
+	t.Run("suggests the closest command", func(t *testing.T) {
+		dispatcher := NewCommandDispatcher()
+		dispatcher.Register(newMockCommand())
+		output, err := dispatcher.Dispatch("mokc", []string{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if output.ExitCode != vo.ExitCodeCommandNotFound {
+			t.Fatalf("expected ExitCodeCommandNotFound, got: %v", output.ExitCode)
+		}
+		if !strings.Contains(output.Message.StripMarkup(), `Did you mean "mock"?`) {
+			t.Errorf("expected a suggestion, got: %q", output.Message.StripMarkup())
+		}
+	})
+
+	t.Run("suggests the closest allowed value", func(t *testing.T) {
+		dispatcher := NewCommandDispatcher()
+		dispatcher.Register(newMockCommand())
+		output, err := dispatcher.Dispatch("mock", []string{"argument-value", "--first=Option-Value"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if output.ExitCode != vo.ExitCodeInvalidUsage {
+			t.Fatalf("expected ExitCodeInvalidUsage, got: %v", output.ExitCode)
+		}
+		if !strings.Contains(output.Message.StripMarkup(), `Did you mean "--first=option-value"?`) {
+			t.Errorf("expected a suggestion, got: %q", output.Message.StripMarkup())
+		}
+	})
+}
+
+type mockAliasedCommand struct {
+	mockCommand
+}
+
+func (m *mockAliasedCommand) GetAliases() []string {
+	return []string{"m"}
+}
+
+func TestCommandDispatcherSubcommands(t *testing.T) {
+	t.Run("dispatches a subcommand of a group", func(t *testing.T) {
+		command := newMockCommand()
+		dispatcher := NewCommandDispatcher()
+		dispatcher.Register(NewCommandGroup("group", "My group", command))
+		output, err := dispatcher.Dispatch("group", []string{"mock", "argument-value"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if output.ExitCode != vo.ExitCodeSuccess {
+			t.Fatalf("expected ExitCodeSuccess, got: %v", output.ExitCode)
+		}
+		if !command.executed || command.input.Arguments["first"].Value != "argument-value" {
+			t.Fatal("expected the subcommand to be executed with its argument")
+		}
+	})
+
+	t.Run("inherits the configuration loader", func(t *testing.T) {
+		configurableCommand := &mockConfigurableCommand{}
+		dispatcher := NewCommandDispatcher()
+		dispatcher.SetConfigurationLoader(func() (*vo.Configuration, error) {
+			return &vo.Configuration{DefaultLanguage: "en_US"}, nil
+		})
+		dispatcher.Register(NewCommandGroup("group", "My group", configurableCommand))
+		_, err := dispatcher.Dispatch("group", []string{"mock", "argument-value"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if configurableCommand.configuration == nil {
+			t.Fatal("expected the configuration to be set")
+		}
+	})
+
+	t.Run("suggests the closest subcommand", func(t *testing.T) {
+		dispatcher := NewCommandDispatcher()
+		dispatcher.Register(NewCommandGroup("group", "My group", newMockCommand()))
+		output, err := dispatcher.Dispatch("group", []string{"mokc"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if output.ExitCode != vo.ExitCodeCommandNotFound {
+			t.Fatalf("expected ExitCodeCommandNotFound, got: %v", output.ExitCode)
+		}
+		message := output.Message.StripMarkup()
+		for _, expected := range []string{"command not found: group mokc", `Did you mean "mock"?`} {
+			if !strings.Contains(message, expected) {
+				t.Errorf("expected message to contain %q, got: %q", expected, message)
+			}
+		}
+	})
+
+	t.Run("dispatches a command by its alias", func(t *testing.T) {
+		command := &mockAliasedCommand{}
+		dispatcher := NewCommandDispatcher()
+		dispatcher.Register(command)
+		output, err := dispatcher.Dispatch("m", []string{"argument-value"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if output.ExitCode != vo.ExitCodeSuccess || !command.executed {
+			t.Fatalf("expected the command to be executed, got: %v", output.ExitCode)
+		}
+	})
 }
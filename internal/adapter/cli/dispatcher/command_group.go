@@ -0,0 +1,67 @@
+package dispatcher
+
+import (
+	"maps"
+	"slices"
+)
+
+// CommandGroup is a parent command, such as config, whose subcommands are
+// dispatched by a child dispatcher. The child inherits the configuration
+// loader and the global options of the dispatcher the group is registered in.
+type CommandGroup struct {
+	name        string
+	description string
+	dispatcher  *CommandDispatcher
+}
+
+func NewCommandGroup(name string, description string, subcommands ...Command) *CommandGroup {
+	commandGroup := &CommandGroup{
+		name:        name,
+		description: description,
+		dispatcher:  newCommandDispatcher(),
+	}
+	commandGroup.dispatcher.name = name
+	for _, subcommand := range subcommands {
+		commandGroup.dispatcher.Register(subcommand)
+	}
+	return commandGroup
+}
+
+func (c *CommandGroup) GetName() string {
+	return c.name
+}
+
+func (c *CommandGroup) GetDescription() string {
+	return c.description
+}
+
+func (c *CommandGroup) GetArguments() []Argument {
+	return []Argument{}
+}
+
+func (c *CommandGroup) GetOptions() []Option {
+	return []Option{}
+}
+
+// Execute lists the subcommands, as a group does nothing by itself.
+func (c *CommandGroup) Execute(_ *CommandInput) (*Result, error) {
+	result := NewResult()
+	result.Message = c.dispatcher.Usage()
+	return result, nil
+}
+
+// GetSubcommands returns the subcommands sorted by name.
+func (c *CommandGroup) GetSubcommands() []Command {
+	subcommands := make([]Command, 0, len(c.dispatcher.commands))
+	for _, name := range slices.Sorted(maps.Keys(c.dispatcher.commands)) {
+		subcommands = append(subcommands, c.dispatcher.commands[name])
+	}
+	return subcommands
+}
+
+func (c *CommandGroup) dispatch(args []string) (*Result, error) {
+	if len(args) == 0 || args[0] == "--help" || args[0] == "-h" {
+		return c.Execute(nil)
+	}
+	return c.dispatcher.Dispatch(args[0], args[1:])
+}
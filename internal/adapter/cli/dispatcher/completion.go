@@ -0,0 +1,267 @@
+package dispatcher
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/yusadeol/go-commit/internal/domain/vo"
+)
+
+// CompleteCommandName is the hidden command run by the completion scripts.
+// Its words are still being typed, so they must reach it unvalidated.
+const CompleteCommandName = "__complete"
+
+// completionScripts only forward the words typed so far to the hidden
+// __complete command, so the candidates always match the registered commands
+// and the values in the configuration.
+var completionScripts = map[string]string{
+	"bash": `# bash completion for commit, generated by "commit completion bash".
+_commit_completion() {
+    local line="${COMP_LINE:0:COMP_POINT}"
+    local -a words
+    read -ra words <<< "$line"
+    if [[ "$line" == *" " ]]; then
+        words+=("")
+    fi
+    # Bash splits words on characters such as "=", so only the part of the
+    # candidate after the current bash word is replaced.
+    local token="${words[${#words[@]}-1]}"
+    local prefix="${token%"${COMP_WORDS[COMP_CWORD]}"}"
+    local candidate
+    COMPREPLY=()
+    while IFS= read -r candidate; do
+        if [[ -n "$candidate" ]]; then
+            COMPREPLY+=("${candidate#"$prefix"}")
+        fi
+    done < <(commit __complete "${words[@]:1}" 2>/dev/null)
+}
+complete -o default -F _commit_completion commit
+`,
+	"zsh": `#compdef commit
+# zsh completion for commit, generated by "commit completion zsh".
+_commit() {
+    local -a candidates
+    candidates=("${(@f)$(commit __complete "${(@)words[2,CURRENT]}" 2>/dev/null)}")
+    candidates=(${candidates:#})
+    if (( ${#candidates} )); then
+        compadd -- "${candidates[@]}"
+    else
+        _files
+    fi
+}
+if [[ "${funcstack[1]}" == "_commit" ]]; then
+    _commit "$@"
+else
+    compdef _commit commit
+fi
+`,
+	"fish": `# fish completion for commit, generated by "commit completion fish".
+function __commit_complete
+    set -l words (commandline -opc)
+    set -l current (commandline -ct)
+    commit __complete $words[2..-1] "$current" 2>/dev/null
+end
+complete -c commit -f -a '(__commit_complete)'
+`,
+}
+
+// RegisterCompletionCommands adds the completion command, which prints the
+// script for a shell, and the hidden command the scripts run.
+func (c *CommandDispatcher) RegisterCompletionCommands() {
+	c.Register(&completionCommand{dispatcher: c})
+	c.Register(&completeCommand{dispatcher: c})
+}
+
+type completionCommand struct {
+	dispatcher *CommandDispatcher
+}
+
+func (c *completionCommand) GetName() string {
+	return "completion"
+}
+
+func (c *completionCommand) GetDescription() string {
+	return "Print the completion script for bash, zsh or fish"
+}
+
+func (c *completionCommand) GetArguments() []Argument {
+	return []Argument{
+		{Name: "shell", Description: "One of: bash, fish, zsh", Required: true},
+	}
+}
+
+func (c *completionCommand) GetOptions() []Option {
+	return []Option{}
+}
+
+func (c *completionCommand) Execute(input *CommandInput) (*Result, error) {
+	result := NewResult()
+	shell := input.Arguments["shell"].Value
+	script, exists := completionScripts[shell]
+	if !exists {
+		shells := slices.Sorted(maps.Keys(completionScripts))
+		lines := []string{fmt.Sprintf("<error>unsupported shell %q. Supported shells are: %s</error>", shell, strings.Join(shells, ", "))}
+		if suggestion := vo.Suggest(shell, shells); suggestion != "" {
+			lines = append(lines, fmt.Sprintf("<info>Did you mean %q?</info>", suggestion))
+		}
+		result.ExitCode = vo.ExitCodeInvalidUsage
+		result.Message = vo.NewColoredMultilineText(lines)
+		return result, nil
+	}
+	result.Message = vo.NewMarkupText(strings.TrimSuffix(script, "\n"))
+	return result, nil
+}
+
+// completeCommand prints the candidates for the last of the words it is
+// given, one per line. It is run by the completion scripts on every tab.
+type completeCommand struct {
+	dispatcher *CommandDispatcher
+}
+
+func (c *completeCommand) GetName() string {
+	return CompleteCommandName
+}
+
+func (c *completeCommand) GetDescription() string {
+	return "Print the completion candidates for the given words"
+}
+
+func (c *completeCommand) GetArguments() []Argument {
+	return []Argument{}
+}
+
+func (c *completeCommand) GetOptions() []Option {
+	return []Option{}
+}
+
+func (c *completeCommand) IsHidden() bool {
+	return true
+}
+
+func (c *completeCommand) Execute(_ *CommandInput) (*Result, error) {
+	return c.dispatch([]string{})
+}
+
+func (c *completeCommand) dispatch(args []string) (*Result, error) {
+	current := ""
+	if len(args) > 0 {
+		current = args[len(args)-1]
+		args = args[:len(args)-1]
+	}
+	result := NewResult()
+	result.Message = vo.NewColoredMultilineText(c.dispatcher.complete(args, current))
+	return result, nil
+}
+
+// complete returns the candidates for the current word after the words
+// already typed: command names, option names or the values of an option.
+// Arguments are left to the shell.
+func (c *CommandDispatcher) complete(words []string, current string) []string {
+	commandDispatcher := c
+	var command Command
+	describesCommand := false
+	previousWord := ""
+	if len(words) > 0 {
+		previousWord = words[len(words)-1]
+	}
+	for len(words) > 0 && command == nil {
+		word := words[0]
+		words = words[1:]
+		if strings.HasPrefix(word, "-") {
+			if _, exists := findValueOption(commandDispatcher.getGlobalOptions(), word); exists && len(words) > 0 {
+				words = words[1:]
+			}
+			continue
+		}
+		found, exists := commandDispatcher.find(word)
+		if !exists {
+			return []string{}
+		}
+		if commandGroup, isGroup := found.(*CommandGroup); isGroup {
+			commandDispatcher = commandGroup.dispatcher
+			continue
+		}
+		if _, isHelp := found.(*helpCommand); isHelp {
+			describesCommand = true
+			continue
+		}
+		command = found
+	}
+	options := append(slices.Clone(commandDispatcher.getGlobalOptions()), helpOption)
+	if command != nil {
+		if describesCommand || slices.Contains(words, "--") {
+			return []string{}
+		}
+		_ = commandDispatcher.configure(command)
+		options = append(command.GetOptions(), options...)
+	}
+	if option, exists := findValueOption(options, previousWord); exists {
+		return filterCompletions(getValueCompletions(option, ""), current)
+	}
+	if name, _, hasValue := strings.Cut(current, "="); hasValue && strings.HasPrefix(name, "--") {
+		option, exists := findValueOption(options, name)
+		if !exists {
+			return []string{}
+		}
+		return filterCompletions(getValueCompletions(option, name+"="), current)
+	}
+	if strings.HasPrefix(current, "-") {
+		return filterCompletions(getOptionCompletions(options), current)
+	}
+	if command == nil {
+		return filterCompletions(commandDispatcher.getVisibleCommandNames(), current)
+	}
+	if _, isCompletion := command.(*completionCommand); isCompletion && len(words) == 0 {
+		return filterCompletions(slices.Sorted(maps.Keys(completionScripts)), current)
+	}
+	return []string{}
+}
+
+// findValueOption returns the option named by a --name or -f word when it
+// takes a value, so the next word is that value.
+func findValueOption(options []Option, word string) (Option, bool) {
+	for _, option := range options {
+		if option.Type == OptionTypeBool {
+			continue
+		}
+		if word == "--"+option.Name || (option.Flag != "" && word == "-"+option.Flag) {
+			return option, true
+		}
+	}
+	return Option{}, false
+}
+
+func getOptionCompletions(options []Option) []string {
+	var completions []string
+	for _, option := range options {
+		completions = append(completions, "--"+option.Name)
+		if option.Type == OptionTypeBool && option.Default == "true" {
+			completions = append(completions, "--no-"+option.Name)
+		}
+	}
+	slices.Sort(completions)
+	return slices.Compact(completions)
+}
+
+func getValueCompletions(option Option, prefix string) []string {
+	values := append(slices.Clone(option.AllowedValues), option.Completions...)
+	slices.Sort(values)
+	values = slices.Compact(values)
+	completions := make([]string, 0, len(values))
+	for _, value := range values {
+		completions = append(completions, prefix+value)
+	}
+	return completions
+}
+
+func filterCompletions(completions []string, current string) []string {
+	filtered := []string{}
+	for _, completion := range completions {
+		if strings.HasPrefix(completion, current) {
+			filtered = append(filtered, completion)
+		}
+	}
+	return filtered
+}
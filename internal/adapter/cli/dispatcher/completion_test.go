@@ -0,0 +1,126 @@
+package dispatcher
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/yusadeol/go-commit/internal/domain/vo"
+)
+
+func TestComplete(t *testing.T) {
+	newDispatcher := func() *CommandDispatcher {
+		dispatcher := NewCommandDispatcher()
+		dispatcher.SetGlobalOptions([]Option{
+			{Name: "config", Description: "Configuration file path"},
+			{Name: "color", Description: "When to color the output", AllowedValues: []string{"always", "auto", "never"}},
+		})
+		dispatcher.Register(newMockCommand())
+		dispatcher.Register(NewCommandGroup("group", "My group", &mockAliasedCommand{}))
+		dispatcher.RegisterCompletionCommands()
+		return dispatcher
+	}
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{name: "commands", args: []string{""}, expected: "completion\ngroup\nhelp\nmock"},
+		{name: "commands with a prefix", args: []string{"m"}, expected: "mock"},
+		{name: "subcommands", args: []string{"group", ""}, expected: "mock"},
+		{name: "commands to describe", args: []string{"help", "g"}, expected: "group"},
+		{name: "options", args: []string{"mock", "--"}, expected: "--color\n--config\n--first\n--help"},
+		{name: "inline values", args: []string{"mock", "--first="}, expected: "--first=default-value\n--first=option-value"},
+		{name: "separated values", args: []string{"mock", "-f", "o"}, expected: "option-value"},
+		{name: "values of a subcommand", args: []string{"group", "m", "--first=d"}, expected: "--first=default-value"},
+		{name: "shells", args: []string{"completion", ""}, expected: "bash\nfish\nzsh"},
+		{name: "arguments", args: []string{"mock", ""}, expected: ""},
+		{name: "after the terminator", args: []string{"mock", "--", "--"}, expected: ""},
+		{name: "unknown commands", args: []string{"unknown", "--"}, expected: ""},
+		{name: "global option values", args: []string{"--color", ""}, expected: "always\nauto\nnever"},
+		{name: "inline global option values", args: []string{"--color=a"}, expected: "--color=always\n--color=auto"},
+		{name: "global option values after a command", args: []string{"mock", "--color", "n"}, expected: "never"},
+		{name: "commands after a global option", args: []string{"--color", "never", "m"}, expected: "mock"},
+		{name: "options after a global option", args: []string{"--config", "team.json", "mock", "--f"}, expected: "--first"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output, err := newDispatcher().Dispatch(CompleteCommandName, test.args)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if output.Message.StripMarkup() != test.expected {
+				t.Errorf("expected %q, got: %q", test.expected, output.Message.StripMarkup())
+			}
+		})
+	}
+
+	t.Run("completes the values from the configuration", func(t *testing.T) {
+		dispatcher := NewCommandDispatcher()
+		dispatcher.SetConfigurationLoader(func() (*vo.Configuration, error) {
+			return &vo.Configuration{Languages: map[string]vo.Language{"pt_BR": {}, "en_US": {}}}, nil
+		})
+		dispatcher.Register(&mockLanguageCommand{})
+		dispatcher.RegisterCompletionCommands()
+		output, err := dispatcher.Dispatch(CompleteCommandName, []string{"mock", "--language="})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := "--language=en_US\n--language=pt_BR"
+		if output.Message.StripMarkup() != expected {
+			t.Errorf("expected %q, got: %q", expected, output.Message.StripMarkup())
+		}
+	})
+}
+
+type mockLanguageCommand struct {
+	mockConfigurableCommand
+}
+
+func (m *mockLanguageCommand) GetOptions() []Option {
+	var languages []string
+	if m.configuration != nil {
+		for language := range m.configuration.Languages {
+			languages = append(languages, language)
+		}
+	}
+	return []Option{{Name: "language", Completions: languages}}
+}
+
+func TestCompletion(t *testing.T) {
+	t.Run("prints the script of a shell", func(t *testing.T) {
+		for _, shell := range []string{"bash", "zsh", "fish"} {
+			dispatcher := NewCommandDispatcher()
+			dispatcher.RegisterCompletionCommands()
+			output, err := dispatcher.Dispatch("completion", []string{shell})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !strings.Contains(output.Message.StripMarkup(), "commit __complete") {
+				t.Errorf("expected the %s script to run __complete, got: %q", shell, output.Message.StripMarkup())
+			}
+		}
+	})
+
+	t.Run("suggests the closest shell", func(t *testing.T) {
+		dispatcher := NewCommandDispatcher()
+		dispatcher.RegisterCompletionCommands()
+		output, err := dispatcher.Dispatch("completion", []string{"zhs"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if output.ExitCode != vo.ExitCodeInvalidUsage {
+			t.Fatalf("expected ExitCodeInvalidUsage, got: %v", output.ExitCode)
+		}
+		if !strings.Contains(output.Message.StripMarkup(), `Did you mean "zsh"?`) {
+			t.Errorf("expected a suggestion, got: %q", output.Message.StripMarkup())
+		}
+	})
+
+	t.Run("hides the complete command from the usage", func(t *testing.T) {
+		dispatcher := NewCommandDispatcher()
+		dispatcher.RegisterCompletionCommands()
+		if strings.Contains(dispatcher.Usage().StripMarkup(), CompleteCommandName) {
+			t.Errorf("expected %s to be hidden, got: %q", CompleteCommandName, dispatcher.Usage().StripMarkup())
+		}
+	})
+}
@@ -0,0 +1,259 @@
+package dispatcher
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/yusadeol/go-commit/internal/domain/vo"
+)
+
+const programDescription = "Automate your Conventional Commit messages with AI"
+
+// docsRenderers render a page in each format, along with the extension of
+// its file.
+var docsRenderers = map[string]struct {
+	extension string
+	render    func(page docsPage) string
+}{
+	"man":      {extension: ".1", render: renderManPage},
+	"markdown": {extension: ".md", render: renderMarkdownPage},
+}
+
+// docsPage describes the top level, a command group or a command, with the
+// same metadata shown by the help.
+type docsPage struct {
+	commandPath   []string
+	description   string
+	synopsis      string
+	aliases       []string
+	arguments     []Argument
+	options       []Option
+	globalOptions []Option
+	commands      []Command
+}
+
+func (d docsPage) getTitle() string {
+	return strings.Join(d.commandPath, " ")
+}
+
+func (d docsPage) getName() string {
+	return strings.Join(d.commandPath, "-")
+}
+
+// RegisterDocsCommand adds the hidden docs command, which renders the
+// reference documentation of every command, so it never drifts from the
+// options actually accepted.
+func (c *CommandDispatcher) RegisterDocsCommand() {
+	c.Register(&docsCommand{dispatcher: c})
+}
+
+type docsCommand struct {
+	dispatcher *CommandDispatcher
+}
+
+func (d *docsCommand) GetName() string {
+	return "docs"
+}
+
+func (d *docsCommand) GetDescription() string {
+	return "Render the reference documentation of every command"
+}
+
+func (d *docsCommand) GetArguments() []Argument {
+	return []Argument{}
+}
+
+func (d *docsCommand) GetOptions() []Option {
+	return []Option{
+		{
+			Name:          "format",
+			Flag:          "f",
+			Description:   "Format of the pages",
+			AllowedValues: []string{"man", "markdown"},
+			Default:       "markdown",
+		},
+		{
+			Name:        "out",
+			Flag:        "o",
+			Description: "Directory to write the pages to",
+			Default:     "docs",
+		},
+	}
+}
+
+func (d *docsCommand) IsHidden() bool {
+	return true
+}
+
+// Execute writes a page for the top level and one for every visible command.
+// The configuration is not loaded, so the pages don't depend on the settings
+// of whoever renders them.
+func (d *docsCommand) Execute(input *CommandInput) (*Result, error) {
+	result := NewResult()
+	renderer := docsRenderers[input.Options["format"].Value]
+	outDirPath := input.Options["out"].Value
+	err := os.MkdirAll(outDirPath, 0755)
+	if err != nil {
+		return nil, err
+	}
+	pages := d.dispatcher.getDocsPages([]string{programName}, programDescription)
+	for _, page := range pages {
+		err = os.WriteFile(filepath.Join(outDirPath, page.getName()+renderer.extension), []byte(renderer.render(page)), 0644)
+		if err != nil {
+			return nil, err
+		}
+	}
+	result.Message = vo.NewMarkupText(fmt.Sprintf("<success>%d pages written to %s</success>", len(pages), outDirPath))
+	return result, nil
+}
+
+// getDocsPages returns the page of the dispatcher followed by the pages of
+// its commands, recursing into command groups.
+func (c *CommandDispatcher) getDocsPages(commandPath []string, description string) []docsPage {
+	page := docsPage{
+		commandPath:   commandPath,
+		description:   description,
+		synopsis:      getGroupSynopsis(strings.Join(commandPath, " ")),
+		globalOptions: append(slices.Clone(c.getGlobalOptions()), helpOption),
+	}
+	var pages []docsPage
+	for _, name := range c.getVisibleCommandNames() {
+		command := c.commands[name]
+		page.commands = append(page.commands, command)
+		subcommandPath := append(slices.Clone(commandPath), name)
+		if commandGroup, isGroup := command.(*CommandGroup); isGroup {
+			pages = append(pages, commandGroup.dispatcher.getDocsPages(subcommandPath, command.GetDescription())...)
+			continue
+		}
+		pages = append(pages, docsPage{
+			commandPath:   subcommandPath,
+			description:   command.GetDescription(),
+			synopsis:      getCommandSynopsis(strings.Join(subcommandPath, " "), command.GetArguments()),
+			aliases:       c.getAliases(name),
+			arguments:     command.GetArguments(),
+			options:       append(command.GetOptions(), helpOption),
+			globalOptions: c.getGlobalOptions(),
+		})
+	}
+	return append([]docsPage{page}, pages...)
+}
+
+func renderMarkdownPage(page docsPage) string {
+	lines := []string{
+		"# " + page.getTitle(),
+		"",
+		page.description,
+		"",
+		"## Usage",
+		"",
+		"```text",
+		page.synopsis,
+		"```",
+	}
+	if len(page.aliases) > 0 {
+		lines = append(lines, "", "## Aliases", "", "`"+strings.Join(page.aliases, "`, `")+"`")
+	}
+	if len(page.arguments) > 0 {
+		rows := make([][2]string, 0, len(page.arguments))
+		for _, argument := range page.arguments {
+			rows = append(rows, [2]string{"`" + argument.Name + "`", argument.Description})
+		}
+		lines = append(lines, renderMarkdownTable("Arguments", "Argument", rows)...)
+	}
+	lines = append(lines, renderMarkdownOptions("Options", page.options)...)
+	lines = append(lines, renderMarkdownOptions("Global options", page.globalOptions)...)
+	if len(page.commands) > 0 {
+		rows := make([][2]string, 0, len(page.commands))
+		for _, command := range page.commands {
+			commandPage := docsPage{commandPath: append(slices.Clone(page.commandPath), command.GetName())}
+			rows = append(rows, [2]string{fmt.Sprintf("[`%s`](%s.md)", command.GetName(), commandPage.getName()), command.GetDescription()})
+		}
+		lines = append(lines, renderMarkdownTable("Commands", "Command", rows)...)
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func renderMarkdownOptions(title string, options []Option) []string {
+	rows := make([][2]string, 0, len(options))
+	for _, option := range options {
+		rows = append(rows, [2]string{"`" + getOptionSynopsis(option) + "`", vo.NewMarkupText(getOptionDescription(option)).StripMarkup()})
+	}
+	return renderMarkdownTable(title, "Option", rows)
+}
+
+func renderMarkdownTable(title string, header string, rows [][2]string) []string {
+	if len(rows) == 0 {
+		return []string{}
+	}
+	lines := []string{"", "## " + title, "", fmt.Sprintf("| %s | Description |", header), "| --- | --- |"}
+	for _, row := range rows {
+		lines = append(lines, fmt.Sprintf("| %s | %s |", strings.ReplaceAll(row[0], "|", `\|`), strings.ReplaceAll(row[1], "|", `\|`)))
+	}
+	return lines
+}
+
+func renderManPage(page docsPage) string {
+	lines := []string{
+		fmt.Sprintf(`.TH "%s" 1 "" "%s" "%s Manual"`, strings.ToUpper(page.getName()), programName, strings.ToUpper(programName[:1])+programName[1:]),
+		".SH NAME",
+		escapeMan(page.getName()) + ` \- ` + escapeMan(page.description),
+		".SH SYNOPSIS",
+		escapeMan(page.synopsis),
+	}
+	if len(page.aliases) > 0 {
+		lines = append(lines, ".SH ALIASES", escapeMan(strings.Join(page.aliases, ", ")))
+	}
+	if len(page.arguments) > 0 {
+		lines = append(lines, ".SH ARGUMENTS")
+		for _, argument := range page.arguments {
+			lines = append(lines, ".TP", ".B "+escapeMan(argument.Name), escapeMan(argument.Description))
+		}
+	}
+	lines = append(lines, renderManOptions("OPTIONS", page.options)...)
+	lines = append(lines, renderManOptions("GLOBAL OPTIONS", page.globalOptions)...)
+	var seeAlso []string
+	if len(page.commandPath) > 1 {
+		seeAlso = append(seeAlso, strings.Join(page.commandPath[:len(page.commandPath)-1], "-"))
+	}
+	if len(page.commands) > 0 {
+		lines = append(lines, ".SH COMMANDS")
+		for _, command := range page.commands {
+			lines = append(lines, ".TP", ".B "+escapeMan(command.GetName()), escapeMan(command.GetDescription()))
+			seeAlso = append(seeAlso, page.getName()+"-"+command.GetName())
+		}
+	}
+	if len(seeAlso) > 0 {
+		references := make([]string, 0, len(seeAlso))
+		for _, name := range seeAlso {
+			references = append(references, fmt.Sprintf(`\fB%s\fR(1)`, escapeMan(name)))
+		}
+		lines = append(lines, ".SH SEE ALSO", strings.Join(references, ", "))
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func renderManOptions(title string, options []Option) []string {
+	if len(options) == 0 {
+		return []string{}
+	}
+	lines := []string{".SH " + title}
+	for _, option := range options {
+		description := vo.NewMarkupText(getOptionDescription(option)).StripMarkup()
+		lines = append(lines, ".TP", ".B "+escapeMan(getOptionSynopsis(option)), escapeMan(description))
+	}
+	return lines
+}
+
+// escapeMan escapes the characters roff would interpret, including a leading
+// dot or quote that would start a request.
+func escapeMan(text string) string {
+	text = strings.ReplaceAll(text, `\`, `\e`)
+	text = strings.ReplaceAll(text, "-", `\-`)
+	if strings.HasPrefix(text, ".") || strings.HasPrefix(text, "'") {
+		text = `\&` + text
+	}
+	return text
+}
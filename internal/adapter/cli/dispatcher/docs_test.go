@@ -0,0 +1,91 @@
+package dispatcher
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDocs(t *testing.T) {
+	newDispatcher := func() *CommandDispatcher {
+		dispatcher := NewCommandDispatcher()
+		dispatcher.SetGlobalOptions([]Option{{Name: "config", Description: "Configuration file path"}})
+		dispatcher.Register(NewCommandGroup("group", "My group", &mockAliasedCommand{}))
+		dispatcher.RegisterDocsCommand()
+		return dispatcher
+	}
+	tests := []struct {
+		format   string
+		fileName string
+		expected []string
+	}{
+		{
+			format:   "markdown",
+			fileName: "commit-group-mock.md",
+			expected: []string{
+				"# commit group mock",
+				"commit group mock [options] [--] <first>",
+				"`m`",
+				"| `first` | My first argument |",
+				`| ` + "`-f, --first=FIRST`" + ` | My first option [allowed: option-value, default-value] [default: "default-value"] |`,
+				"| `--config=CONFIG` | Configuration file path |",
+			},
+		},
+		{
+			format:   "man",
+			fileName: "commit-group-mock.1",
+			expected: []string{
+				`.TH "COMMIT-GROUP-MOCK" 1`,
+				`commit\-group\-mock \- My mock command`,
+				".B \\-f, \\-\\-first=FIRST",
+				`\fBcommit\-group\fR(1)`,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run("renders "+test.format+" pages", func(t *testing.T) {
+			outDirPath := t.TempDir()
+			_, err := newDispatcher().Dispatch("docs", []string{"--format", test.format, "--out", outDirPath})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			data, err := os.ReadFile(filepath.Join(outDirPath, test.fileName))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, expected := range test.expected {
+				if !strings.Contains(string(data), expected) {
+					t.Errorf("expected %s to contain %q, got: %q", test.fileName, expected, string(data))
+				}
+			}
+		})
+	}
+
+	t.Run("links the pages of the subcommands and skips hidden commands", func(t *testing.T) {
+		outDirPath := t.TempDir()
+		_, err := newDispatcher().Dispatch("docs", []string{"--out=" + outDirPath})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		entries, err := os.ReadDir(outDirPath)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var fileNames []string
+		for _, entry := range entries {
+			fileNames = append(fileNames, entry.Name())
+		}
+		expectedFileNames := "commit-group-mock.md commit-group.md commit-help.md commit.md"
+		if strings.Join(fileNames, " ") != expectedFileNames {
+			t.Fatalf("expected %q, got: %q", expectedFileNames, strings.Join(fileNames, " "))
+		}
+		data, err := os.ReadFile(filepath.Join(outDirPath, "commit-group.md"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.Contains(string(data), "| [`mock`](commit-group-mock.md) | My mock command |") {
+			t.Errorf("expected the group to link its subcommands, got: %q", string(data))
+		}
+	})
+}
@@ -0,0 +1,229 @@
+package dispatcher
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/yusadeol/go-commit/internal/domain/vo"
+)
+
+const (
+	programName     = "commit"
+	helpCommandName = "help"
+)
+
+var helpOption = Option{Name: "help", Flag: "h", Description: "Display the help of the command", Type: OptionTypeBool}
+
+// helpCommand is registered by every dispatcher, so its output always lists
+// the commands actually available.
+type helpCommand struct {
+	dispatcher *CommandDispatcher
+}
+
+func (h *helpCommand) GetName() string {
+	return helpCommandName
+}
+
+func (h *helpCommand) GetDescription() string {
+	return "Display the available commands or the help of a command"
+}
+
+func (h *helpCommand) GetArguments() []Argument {
+	return []Argument{
+		{Name: "command", Description: "Command to describe", Required: false},
+		{Name: "subcommand", Description: "Subcommand to describe, for command groups", Required: false},
+	}
+}
+
+func (h *helpCommand) GetOptions() []Option {
+	return []Option{}
+}
+
+func (h *helpCommand) Execute(input *CommandInput) (*Result, error) {
+	commandName := input.Arguments["command"].Value
+	if commandName == "" {
+		result := NewResult()
+		result.Message = h.dispatcher.Usage()
+		return result, nil
+	}
+	subcommandName := input.Arguments["subcommand"].Value
+	if subcommandName == "" {
+		return h.dispatcher.Help(commandName), nil
+	}
+	return h.dispatcher.Help(commandName, subcommandName), nil
+}
+
+// Usage lists the global options and every registered command. For command
+// groups it starts with their description and lists their subcommands.
+func (c *CommandDispatcher) Usage() *vo.MarkupText {
+	commandLine := strings.Join(append([]string{programName}, c.commandPath()...), " ")
+	var lines []string
+	if c.parent != nil {
+		if command, exists := c.parent.find(c.name); exists {
+			lines = append(lines, "<comment>Description:</comment>", "  "+command.GetDescription(), "")
+		}
+	}
+	lines = append(lines,
+		"<comment>Usage:</comment>",
+		"  "+getGroupSynopsis(commandLine),
+	)
+	if globalOptions := c.getGlobalOptions(); len(globalOptions) > 0 {
+		lines = append(lines, "", "<comment>Global options:</comment>")
+		lines = append(lines, formatOptions(append(slices.Clone(globalOptions), helpOption))...)
+	}
+	lines = append(lines, "", "<comment>Available commands:</comment>")
+	var rows [][2]string
+	for _, name := range c.getVisibleCommandNames() {
+		description := c.commands[name].GetDescription()
+		if aliases := c.getAliases(name); len(aliases) > 0 {
+			description += fmt.Sprintf(" <comment>[aliases: %s]</comment>", strings.Join(aliases, ", "))
+		}
+		rows = append(rows, [2]string{name, description})
+	}
+	lines = append(lines, formatRows(rows)...)
+	helpCommandLine := strings.Join(append([]string{programName, helpCommandName}, c.commandPath()...), " ")
+	lines = append(lines, "", fmt.Sprintf("Run \"%s <command>\" for the arguments and options of a command.", helpCommandLine))
+	return vo.NewColoredMultilineText(lines)
+}
+
+// Help describes a command from its arguments and options, or lists the
+// subcommands of a command group. Commands that need the configuration get it
+// when it can be loaded, so their defaults are shown.
+func (c *CommandDispatcher) Help(commandName string, subcommandNames ...string) *Result {
+	command, exists := c.find(commandName)
+	if !exists {
+		return c.commandNotFound(commandName)
+	}
+	if commandGroup, isGroup := command.(*CommandGroup); isGroup {
+		if len(subcommandNames) > 0 {
+			return commandGroup.dispatcher.Help(subcommandNames[0], subcommandNames[1:]...)
+		}
+		result := NewResult()
+		result.Message = commandGroup.dispatcher.Usage()
+		return result
+	}
+	_ = c.configure(command)
+	result := NewResult()
+	result.Message = c.commandUsage(command)
+	return result
+}
+
+// getAliases returns the aliases registered for a command, sorted.
+func (c *CommandDispatcher) getAliases(commandName string) []string {
+	var aliases []string
+	for alias, name := range c.aliases {
+		if name == commandName {
+			aliases = append(aliases, alias)
+		}
+	}
+	slices.Sort(aliases)
+	return aliases
+}
+
+func (c *CommandDispatcher) commandUsage(command Command) *vo.MarkupText {
+	commandLine := strings.Join(append(append([]string{programName}, c.commandPath()...), command.GetName()), " ")
+	var argumentRows [][2]string
+	for _, argument := range command.GetArguments() {
+		argumentRows = append(argumentRows, [2]string{argument.Name, argument.Description})
+	}
+	lines := []string{
+		"<comment>Description:</comment>",
+		"  " + command.GetDescription(),
+		"",
+		"<comment>Usage:</comment>",
+		"  " + getCommandSynopsis(commandLine, command.GetArguments()),
+	}
+	if aliases := c.getAliases(command.GetName()); len(aliases) > 0 {
+		lines = append(lines, "", "<comment>Aliases:</comment>", "  "+strings.Join(aliases, ", "))
+	}
+	if len(argumentRows) > 0 {
+		lines = append(lines, "", "<comment>Arguments:</comment>")
+		lines = append(lines, formatRows(argumentRows)...)
+	}
+	lines = append(lines, "", "<comment>Options:</comment>")
+	lines = append(lines, formatOptions(append(command.GetOptions(), helpOption))...)
+	if globalOptions := c.getGlobalOptions(); len(globalOptions) > 0 {
+		lines = append(lines, "", "<comment>Global options:</comment>")
+		lines = append(lines, formatOptions(globalOptions)...)
+	}
+	return vo.NewColoredMultilineText(lines)
+}
+
+// getGroupSynopsis returns the usage line of the top level or of a command
+// group, which are followed by a command.
+func getGroupSynopsis(commandLine string) string {
+	return commandLine + " <command> [options] [arguments]"
+}
+
+func getCommandSynopsis(commandLine string, arguments []Argument) string {
+	synopsis := commandLine + " [options]"
+	if len(arguments) > 0 {
+		synopsis += " [--]"
+	}
+	for _, argument := range arguments {
+		if argument.Required {
+			synopsis += fmt.Sprintf(" <%s>", argument.Name)
+		} else {
+			synopsis += fmt.Sprintf(" [<%s>]", argument.Name)
+		}
+	}
+	return synopsis
+}
+
+// getOptionSynopsis returns how an option is written, such as
+// -l, --language=LANGUAGE or --[no-]commit.
+func getOptionSynopsis(option Option) string {
+	synopsis := "--" + option.Name
+	if option.Type == OptionTypeBool && option.Default == "true" {
+		synopsis = "--[no-]" + option.Name
+	}
+	if option.Flag != "" {
+		synopsis = fmt.Sprintf("-%s, %s", option.Flag, synopsis)
+	}
+	if option.Type != OptionTypeBool {
+		synopsis += "=" + strings.ToUpper(strings.ReplaceAll(option.Name, "-", "_"))
+	}
+	return synopsis
+}
+
+// getOptionDescription appends the constraints of an option to its
+// description, in comment markup.
+func getOptionDescription(option Option) string {
+	description := option.Description
+	if option.Repeated {
+		description += " <comment>(multiple values allowed)</comment>"
+	}
+	if len(option.AllowedValues) > 0 {
+		description += fmt.Sprintf(" <comment>[allowed: %s]</comment>", strings.Join(option.AllowedValues, ", "))
+	}
+	if option.Default != "" {
+		description += fmt.Sprintf(" <comment>[default: %q]</comment>", option.Default)
+	}
+	return description
+}
+
+func formatOptions(options []Option) []string {
+	rows := make([][2]string, 0, len(options))
+	for _, option := range options {
+		name := getOptionSynopsis(option)
+		if option.Flag == "" {
+			name = "    " + name
+		}
+		rows = append(rows, [2]string{name, getOptionDescription(option)})
+	}
+	return formatRows(rows)
+}
+
+// formatRows aligns the descriptions in a second column.
+func formatRows(rows [][2]string) []string {
+	width := 0
+	for _, row := range rows {
+		width = max(width, len(row[0]))
+	}
+	lines := make([]string, 0, len(rows))
+	for _, row := range rows {
+		lines = append(lines, fmt.Sprintf("  <info>%s</info>%s  %s", row[0], strings.Repeat(" ", width-len(row[0])), row[1]))
+	}
+	return lines
+}
@@ -0,0 +1,143 @@
+package dispatcher
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/yusadeol/go-commit/internal/domain/vo"
+)
+
+func TestHelp(t *testing.T) {
+	t.Run("lists the global options and the commands", func(t *testing.T) {
+		dispatcher := NewCommandDispatcher()
+		dispatcher.SetGlobalOptions([]Option{{Name: "config", Description: "Configuration file path"}})
+		dispatcher.Register(newMockCommand())
+		output, err := dispatcher.Dispatch("help", []string{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		usage := output.Message.StripMarkup()
+		for _, expected := range []string{"--config=CONFIG", "mock  My mock command", "help  Display the available commands"} {
+			if !strings.Contains(usage, expected) {
+				t.Errorf("expected usage to contain %q, got: %q", expected, usage)
+			}
+		}
+	})
+
+	t.Run("describes a command from its metadata", func(t *testing.T) {
+		dispatcher := NewCommandDispatcher()
+		dispatcher.Register(newMockCommand())
+		output, err := dispatcher.Dispatch("help", []string{"mock"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		help := output.Message.StripMarkup()
+		for _, expected := range []string{
+			"My mock command",
+			"commit mock [options] [--] <first>",
+			"first  My first argument",
+			`-f, --first=FIRST  My first option [allowed: option-value, default-value] [default: "default-value"]`,
+			"-h, --help",
+		} {
+			if !strings.Contains(help, expected) {
+				t.Errorf("expected help to contain %q, got: %q", expected, help)
+			}
+		}
+	})
+
+	t.Run("shows the help instead of executing the command", func(t *testing.T) {
+		for _, arg := range []string{"--help", "-h"} {
+			command := newMockCommand()
+			dispatcher := NewCommandDispatcher()
+			dispatcher.Register(command)
+			output, err := dispatcher.Dispatch("mock", []string{arg})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if command.executed {
+				t.Fatal("expected command not to be executed")
+			}
+			if !strings.Contains(output.Message.StripMarkup(), "My first option") {
+				t.Errorf("expected the command help, got: %q", output.Message.StripMarkup())
+			}
+		}
+	})
+
+	t.Run("shows the help even when the configuration is missing", func(t *testing.T) {
+		dispatcher := NewCommandDispatcher()
+		dispatcher.SetConfigurationLoader(func() (*vo.Configuration, error) {
+			return nil, vo.ErrConfigurationNotFound
+		})
+		dispatcher.Register(&mockConfigurableCommand{})
+		output, err := dispatcher.Dispatch("mock", []string{"--help"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if output.ExitCode != vo.ExitCodeSuccess {
+			t.Fatalf("expected ExitCodeSuccess, got: %v", output.ExitCode)
+		}
+	})
+
+	t.Run("returns command not found for unknown commands", func(t *testing.T) {
+		dispatcher := NewCommandDispatcher()
+		output, err := dispatcher.Dispatch("help", []string{"unknown"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if output.ExitCode != vo.ExitCodeCommandNotFound {
+			t.Fatalf("expected ExitCodeCommandNotFound, got: %v", output.ExitCode)
+		}
+	})
+}
+
+func TestHelpSubcommands(t *testing.T) {
+	newDispatcher := func() *CommandDispatcher {
+		dispatcher := NewCommandDispatcher()
+		dispatcher.SetGlobalOptions([]Option{{Name: "config", Description: "Configuration file path"}})
+		dispatcher.Register(NewCommandGroup("group", "My group", &mockAliasedCommand{}))
+		return dispatcher
+	}
+
+	t.Run("lists the subcommands of a group", func(t *testing.T) {
+		for _, args := range [][]string{{}, {"--help"}} {
+			output, err := newDispatcher().Dispatch("group", args)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			usage := output.Message.StripMarkup()
+			for _, expected := range []string{
+				"My group",
+				"commit group <command> [options] [arguments]",
+				"--config=CONFIG",
+				"mock  My mock command [aliases: m]",
+				`Run "commit help group <command>"`,
+			} {
+				if !strings.Contains(usage, expected) {
+					t.Errorf("expected usage to contain %q, got: %q", expected, usage)
+				}
+			}
+		}
+	})
+
+	t.Run("describes a subcommand with the inherited global options", func(t *testing.T) {
+		for _, dispatch := range []func(dispatcher *CommandDispatcher) (*Result, error){
+			func(dispatcher *CommandDispatcher) (*Result, error) {
+				return dispatcher.Dispatch("help", []string{"group", "mock"})
+			},
+			func(dispatcher *CommandDispatcher) (*Result, error) {
+				return dispatcher.Dispatch("group", []string{"m", "-h"})
+			},
+		} {
+			output, err := dispatch(newDispatcher())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			help := output.Message.StripMarkup()
+			for _, expected := range []string{"commit group mock [options] [--] <first>", "Aliases:", "--config=CONFIG"} {
+				if !strings.Contains(help, expected) {
+					t.Errorf("expected help to contain %q, got: %q", expected, help)
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,261 @@
+package dispatcher
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/yusadeol/go-commit/internal/domain/vo"
+)
+
+// usageError describes an invalid command line, along with the closest valid
+// input when one is close enough to be a likely typo.
+type usageError struct {
+	message    string
+	suggestion string
+}
+
+func (u *usageError) Error() string {
+	return u.message
+}
+
+// errHelpRequested is returned instead of the input when -h or --help is
+// given as an option, so help wins over any missing argument.
+var errHelpRequested = errors.New("help requested")
+
+// argsParser parses the command line following the GNU conventions: long
+// options as --name=value or --name value, short ones as -f value, -fvalue or
+// clustered as -abc, --no-name for bool options and -- to end the options.
+type argsParser struct {
+	options []Option
+	values  map[string][]string
+}
+
+// parseArgs also accepts the help option, reporting it with errHelpRequested.
+// It is only recognized where an option is, so the value of an option such
+// as --model -h is never mistaken for it.
+func parseArgs(arguments []Argument, options []Option, args []string) (*CommandInput, error) {
+	parser := &argsParser{options: append(slices.Clone(options), helpOption), values: map[string][]string{}}
+	var positionalArgs []string
+	for index := 0; index < len(args); index++ {
+		arg := args[index]
+		consumedArgs := 0
+		var err error
+		switch {
+		case arg == "--":
+			positionalArgs = append(positionalArgs, args[index+1:]...)
+			index = len(args)
+		case strings.HasPrefix(arg, "--"):
+			consumedArgs, err = parser.parseLongOption(arg[2:], args[index+1:])
+		case strings.HasPrefix(arg, "-") && arg != "-" && !parser.isNegativeNumber(arg):
+			consumedArgs, err = parser.parseShortOptions(arg[1:], args[index+1:])
+		default:
+			positionalArgs = append(positionalArgs, arg)
+		}
+		if err != nil {
+			return nil, err
+		}
+		index += consumedArgs
+	}
+	if len(parser.values[helpOption.Name]) > 0 {
+		return nil, errHelpRequested
+	}
+	argumentInputs, err := parser.bindArguments(arguments, positionalArgs)
+	if err != nil {
+		return nil, err
+	}
+	optionInputs, err := parser.bindOptions()
+	if err != nil {
+		return nil, err
+	}
+	return NewCommandInput(argumentInputs, optionInputs), nil
+}
+
+// parseLongOption returns how many of the following args it used as value.
+func (a *argsParser) parseLongOption(body string, nextArgs []string) (int, error) {
+	name, value, hasValue := strings.Cut(body, "=")
+	option, exists := a.findOption(func(option Option) bool { return option.Name == name })
+	if !exists {
+		negatedName, isNegated := strings.CutPrefix(name, "no-")
+		option, exists = a.findOption(func(option Option) bool { return option.Name == negatedName })
+		if !isNegated || !exists || option.Type != OptionTypeBool {
+			return 0, a.unknownOptionError(name)
+		}
+		if hasValue {
+			return 0, fmt.Errorf("option --%s does not take a value", name)
+		}
+		a.values[option.Name] = append(a.values[option.Name], "false")
+		return 0, nil
+	}
+	if hasValue {
+		a.values[option.Name] = append(a.values[option.Name], value)
+		return 0, nil
+	}
+	return a.takeValue(option, nextArgs)
+}
+
+func (a *argsParser) parseShortOptions(cluster string, nextArgs []string) (int, error) {
+	for index, flag := range cluster {
+		option, exists := a.findOption(func(option Option) bool { return option.Flag == string(flag) })
+		if !exists {
+			return 0, a.unknownFlagError(string(flag))
+		}
+		value := strings.TrimPrefix(cluster[index+utf8.RuneLen(flag):], "=")
+		if option.Type == OptionTypeBool && !strings.HasPrefix(cluster[index+utf8.RuneLen(flag):], "=") {
+			a.values[option.Name] = append(a.values[option.Name], "true")
+			continue
+		}
+		if value != "" {
+			a.values[option.Name] = append(a.values[option.Name], value)
+			return 0, nil
+		}
+		return a.takeValue(option, nextArgs)
+	}
+	return 0, nil
+}
+
+// takeValue uses the next arg as the value, even when it starts with a dash,
+// as options that expect a value always take one.
+func (a *argsParser) takeValue(option Option, nextArgs []string) (int, error) {
+	if option.Type == OptionTypeBool {
+		a.values[option.Name] = append(a.values[option.Name], "true")
+		return 0, nil
+	}
+	if len(nextArgs) == 0 {
+		return 0, fmt.Errorf("missing value for option: %s", option.Name)
+	}
+	a.values[option.Name] = append(a.values[option.Name], nextArgs[0])
+	return 1, nil
+}
+
+// isNegativeNumber tells values such as -5 apart from short options, unless
+// a digit is used as a flag.
+func (a *argsParser) isNegativeNumber(arg string) bool {
+	_, err := strconv.ParseFloat(arg, 64)
+	if err != nil {
+		return false
+	}
+	_, isFlag := a.findOption(func(option Option) bool { return option.Flag == arg[1:2] })
+	return !isFlag
+}
+
+func (a *argsParser) unknownOptionError(name string) error {
+	err := &usageError{message: fmt.Sprintf("unknown option: %s", name)}
+	if _, isFlag := a.findOption(func(option Option) bool { return option.Flag == name }); isFlag {
+		err.suggestion = "-" + name
+		return err
+	}
+	var names []string
+	for _, option := range a.options {
+		names = append(names, option.Name)
+		if option.Type == OptionTypeBool {
+			names = append(names, "no-"+option.Name)
+		}
+	}
+	if suggestion := vo.Suggest(name, names); suggestion != "" {
+		err.suggestion = "--" + suggestion
+	}
+	return err
+}
+
+// unknownFlagError only suggests flags differing in case, as any other single
+// letter is as close as the next one.
+func (a *argsParser) unknownFlagError(flag string) error {
+	err := &usageError{message: fmt.Sprintf("unknown option: %s", flag)}
+	option, exists := a.findOption(func(option Option) bool { return option.Flag != "" && strings.EqualFold(option.Flag, flag) })
+	if exists {
+		err.suggestion = "-" + option.Flag
+	}
+	return err
+}
+
+func (a *argsParser) findOption(matches func(option Option) bool) (Option, bool) {
+	index := slices.IndexFunc(a.options, matches)
+	if index < 0 {
+		return Option{}, false
+	}
+	return a.options[index], true
+}
+
+func (a *argsParser) bindArguments(arguments []Argument, positionalArgs []string) (map[string]ArgumentInput, error) {
+	argumentInputs := make(map[string]ArgumentInput, len(positionalArgs))
+	for index, argument := range arguments {
+		if index < len(positionalArgs) {
+			argumentInputs[argument.Name] = ArgumentInput{Value: positionalArgs[index], Meta: argument}
+			continue
+		}
+		if argument.Required {
+			return nil, fmt.Errorf("missing required argument: %s", argument.Name)
+		}
+	}
+	if len(positionalArgs) > len(arguments) {
+		return nil, fmt.Errorf("unexpected argument: %s", positionalArgs[len(arguments)])
+	}
+	return argumentInputs, nil
+}
+
+// bindOptions validates the values of every option. Options that are not
+// repeated only keep their last value, once every value given is valid.
+func (a *argsParser) bindOptions() (map[string]OptionInput, error) {
+	optionInputs := make(map[string]OptionInput, len(a.options))
+	for _, option := range a.options {
+		values := a.values[option.Name]
+		for index, value := range values {
+			normalizedValue, err := a.validateValue(option, value)
+			if err != nil {
+				return nil, err
+			}
+			values[index] = normalizedValue
+		}
+		if len(values) > 1 && !option.Repeated {
+			values = values[len(values)-1:]
+		}
+		if len(values) == 0 && option.Default != "" {
+			values = []string{option.Default}
+		}
+		optionInput := OptionInput{Values: values, Meta: option}
+		if len(values) > 0 {
+			optionInput.Value = values[len(values)-1]
+		} else if option.Type == OptionTypeBool {
+			optionInput.Value = "false"
+		}
+		optionInputs[option.Name] = optionInput
+	}
+	return optionInputs, nil
+}
+
+func (a *argsParser) validateValue(option Option, value string) (string, error) {
+	switch option.Type {
+	case OptionTypeBool:
+		boolValue, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", fmt.Errorf("invalid value for option %q: %q. Expected true or false", option.Name, value)
+		}
+		return strconv.FormatBool(boolValue), nil
+	case OptionTypeInt:
+		_, err := strconv.Atoi(value)
+		if err != nil {
+			return "", fmt.Errorf("invalid value for option %q: %q. Expected an integer", option.Name, value)
+		}
+	case OptionTypeDuration:
+		_, err := time.ParseDuration(value)
+		if err != nil {
+			return "", fmt.Errorf("invalid value for option %q: %q. Expected a duration such as 30s or 5m", option.Name, value)
+		}
+	}
+	if len(option.AllowedValues) > 0 && !slices.Contains(option.AllowedValues, value) {
+		err := &usageError{message: fmt.Sprintf(
+			"invalid value for option %q: %q. Allowed values are: %s",
+			option.Name, value, strings.Join(option.AllowedValues, ", "),
+		)}
+		if suggestion := vo.Suggest(value, option.AllowedValues); suggestion != "" {
+			err.suggestion = fmt.Sprintf("--%s=%s", option.Name, suggestion)
+		}
+		return "", err
+	}
+	return value, nil
+}
@@ -0,0 +1,289 @@
+package dispatcher
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+var parserTestArguments = []Argument{
+	{Name: "first", Description: "First argument"},
+	{Name: "second", Description: "Second argument"},
+}
+
+var parserTestOptions = []Option{
+	{Name: "provider", Flag: "p", Description: "Provider"},
+	{Name: "language", Flag: "l", Description: "Language", AllowedValues: []string{"en_US", "pt_BR"}},
+	{Name: "commit", Flag: "c", Description: "Commit", Type: OptionTypeBool, Default: "true"},
+	{Name: "verbose", Flag: "v", Description: "Verbose", Type: OptionTypeBool},
+	{Name: "count", Flag: "n", Description: "Count", Type: OptionTypeInt, Default: "5"},
+	{Name: "timeout", Description: "Timeout", Type: OptionTypeDuration},
+	{Name: "exclude", Flag: "x", Description: "Exclude", Repeated: true},
+}
+
+func TestParseArgs(t *testing.T) {
+	tests := []struct {
+		name              string
+		args              []string
+		expectedArguments map[string]string
+		expectedOptions   map[string]string
+		expectedValues    map[string][]string
+	}{
+		{
+			name:            "defaults",
+			args:            []string{},
+			expectedOptions: map[string]string{"provider": "", "commit": "true", "verbose": "false", "count": "5"},
+		},
+		{
+			name:            "long option with inline value",
+			args:            []string{"--provider=openai"},
+			expectedOptions: map[string]string{"provider": "openai"},
+		},
+		{
+			name:              "long option with separate value",
+			args:              []string{"--provider", "openai", "diff"},
+			expectedArguments: map[string]string{"first": "diff"},
+			expectedOptions:   map[string]string{"provider": "openai"},
+		},
+		{
+			name:              "bool flag does not consume the next argument",
+			args:              []string{"--commit", "diff"},
+			expectedArguments: map[string]string{"first": "diff"},
+			expectedOptions:   map[string]string{"commit": "true"},
+		},
+		{
+			name:            "bool flag with inline value",
+			args:            []string{"--commit=false", "--verbose=1"},
+			expectedOptions: map[string]string{"commit": "false", "verbose": "true"},
+		},
+		{
+			name:            "negated bool flag",
+			args:            []string{"--no-commit"},
+			expectedOptions: map[string]string{"commit": "false"},
+		},
+		{
+			name:            "negated bool option",
+			args:            []string{"--no-commit", "-p", "first"},
+			expectedOptions: map[string]string{"commit": "false", "provider": "first"},
+		},
+		{
+			name:            "short option with separate value",
+			args:            []string{"-p", "openai", "-l", "pt_BR"},
+			expectedOptions: map[string]string{"provider": "openai", "language": "pt_BR"},
+		},
+		{
+			name:            "short option with attached value",
+			args:            []string{"-popenai", "-l=pt_BR"},
+			expectedOptions: map[string]string{"provider": "openai", "language": "pt_BR"},
+		},
+		{
+			name:            "short bool option with inline value",
+			args:            []string{"-c=false"},
+			expectedOptions: map[string]string{"commit": "false"},
+		},
+		{
+			name:            "clustered short options",
+			args:            []string{"-vcp", "openai"},
+			expectedOptions: map[string]string{"verbose": "true", "commit": "true", "provider": "openai"},
+		},
+		{
+			name:            "clustered short options ending with an attached value",
+			args:            []string{"-vpopenai"},
+			expectedOptions: map[string]string{"verbose": "true", "provider": "openai"},
+		},
+		{
+			name:              "terminator",
+			args:              []string{"-v", "--", "--commit", "-p"},
+			expectedArguments: map[string]string{"first": "--commit", "second": "-p"},
+			expectedOptions:   map[string]string{"verbose": "true", "commit": "true", "provider": ""},
+		},
+		{
+			name:              "single dash is an argument",
+			args:              []string{"-"},
+			expectedArguments: map[string]string{"first": "-"},
+		},
+		{
+			name:            "last value wins",
+			args:            []string{"-p", "first", "--provider=second"},
+			expectedOptions: map[string]string{"provider": "second"},
+			expectedValues:  map[string][]string{"provider": {"second"}},
+		},
+		{
+			name:            "bool option given twice",
+			args:            []string{"--no-commit", "--commit"},
+			expectedOptions: map[string]string{"commit": "true"},
+		},
+		{
+			name:            "last negative value wins",
+			args:            []string{"--count", "-3", "-n", "-4"},
+			expectedOptions: map[string]string{"count": "-4"},
+		},
+		{
+			name:            "negative value",
+			args:            []string{"--count", "-3"},
+			expectedOptions: map[string]string{"count": "-3"},
+		},
+		{
+			name:              "negative number as argument",
+			args:              []string{"-7"},
+			expectedArguments: map[string]string{"first": "-7"},
+		},
+		{
+			name:            "value starting with a dash",
+			args:            []string{"--provider", "--verbose"},
+			expectedOptions: map[string]string{"provider": "--verbose", "verbose": "false"},
+		},
+		{
+			name:            "duration",
+			args:            []string{"--timeout=1m30s"},
+			expectedOptions: map[string]string{"timeout": "1m30s"},
+		},
+		{
+			name:           "repeated values",
+			args:           []string{"-x", "go.sum", "--exclude=*.lock", "-xvendor"},
+			expectedValues: map[string][]string{"exclude": {"go.sum", "*.lock", "vendor"}},
+		},
+		{
+			name:            "help flag as a value",
+			args:            []string{"--provider", "-h"},
+			expectedOptions: map[string]string{"provider": "-h"},
+		},
+		{
+			name:              "options between arguments",
+			args:              []string{"one", "-v", "two"},
+			expectedArguments: map[string]string{"first": "one", "second": "two"},
+			expectedOptions:   map[string]string{"verbose": "true"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			input, err := parseArgs(parserTestArguments, parserTestOptions, test.args)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for name, expected := range test.expectedArguments {
+				if input.Arguments[name].Value != expected {
+					t.Errorf("expected argument %q to be %q, got: %q", name, expected, input.Arguments[name].Value)
+				}
+			}
+			if test.expectedArguments == nil && len(input.Arguments) > 0 {
+				t.Errorf("expected no arguments, got: %v", input.Arguments)
+			}
+			for name, expected := range test.expectedOptions {
+				if input.Options[name].Value != expected {
+					t.Errorf("expected option %q to be %q, got: %q", name, expected, input.Options[name].Value)
+				}
+			}
+			for name, expected := range test.expectedValues {
+				if !slices.Equal(input.Options[name].Values, expected) {
+					t.Errorf("expected option %q values to be %v, got: %v", name, expected, input.Options[name].Values)
+				}
+			}
+		})
+	}
+}
+
+func TestParseArgsErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		expectedError string
+	}{
+		{name: "unknown long option", args: []string{"--unknown"}, expectedError: "unknown option: unknown"},
+		{name: "unknown short option", args: []string{"-vz"}, expectedError: "unknown option: z"},
+		{name: "negated option that is not bool", args: []string{"--no-provider"}, expectedError: "unknown option: no-provider"},
+		{name: "negated option with a value", args: []string{"--no-commit=true"}, expectedError: "option --no-commit does not take a value"},
+		{name: "missing value", args: []string{"--provider"}, expectedError: "missing value for option: provider"},
+		{name: "missing value in a cluster", args: []string{"-vp"}, expectedError: "missing value for option: provider"},
+		{name: "invalid bool", args: []string{"--commit=maybe"}, expectedError: `invalid value for option "commit": "maybe". Expected true or false`},
+		{name: "invalid int", args: []string{"--count=many"}, expectedError: `invalid value for option "count": "many". Expected an integer`},
+		{name: "invalid duration", args: []string{"--timeout=soon"}, expectedError: `invalid value for option "timeout": "soon". Expected a duration such as 30s or 5m`},
+		{name: "value not allowed", args: []string{"-l", "de_DE"}, expectedError: `invalid value for option "language": "de_DE". Allowed values are: en_US, pt_BR`},
+		{name: "invalid value overridden later", args: []string{"--count=many", "--count=3"}, expectedError: `invalid value for option "count": "many". Expected an integer`},
+		{name: "too many arguments", args: []string{"one", "two", "three"}, expectedError: "unexpected argument: three"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := parseArgs(parserTestArguments, parserTestOptions, test.args)
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			if err.Error() != test.expectedError {
+				t.Errorf("expected error %q, got: %q", test.expectedError, err.Error())
+			}
+		})
+	}
+
+	t.Run("missing required argument", func(t *testing.T) {
+		_, err := parseArgs([]Argument{{Name: "first", Required: true}}, parserTestOptions, []string{"-v"})
+		if err == nil || err.Error() != "missing required argument: first" {
+			t.Errorf("expected missing required argument error, got: %v", err)
+		}
+	})
+}
+
+func TestParseArgsHelp(t *testing.T) {
+	requiredArguments := []Argument{{Name: "first", Required: true}}
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "long option", args: []string{"--help"}},
+		{name: "short option", args: []string{"-h"}},
+		{name: "short option in a cluster", args: []string{"-vh"}},
+		{name: "after an invalid value", args: []string{"--count=many", "-h"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := parseArgs(requiredArguments, parserTestOptions, test.args)
+			if !errors.Is(err, errHelpRequested) {
+				t.Errorf("expected errHelpRequested, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestOptionInputConversions(t *testing.T) {
+	input, err := parseArgs(nil, parserTestOptions, []string{"--no-commit", "-n", "7", "--timeout", "2s"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if input.Options["commit"].Bool() {
+		t.Error("expected commit to be false")
+	}
+	if input.Options["count"].Int() != 7 {
+		t.Errorf("expected count 7, got: %d", input.Options["count"].Int())
+	}
+	if input.Options["timeout"].Duration() != 2*time.Second {
+		t.Errorf("expected timeout 2s, got: %v", input.Options["timeout"].Duration())
+	}
+}
+
+func TestParseArgsSuggestions(t *testing.T) {
+	tests := []struct {
+		name               string
+		args               []string
+		expectedSuggestion string
+	}{
+		{name: "misspelled option", args: []string{"--langauge=pt_BR"}, expectedSuggestion: "--language"},
+		{name: "misspelled negation", args: []string{"--no-comit"}, expectedSuggestion: "--no-commit"},
+		{name: "flag written as long option", args: []string{"--p", "openai"}, expectedSuggestion: "-p"},
+		{name: "flag in the wrong case", args: []string{"-V"}, expectedSuggestion: "-v"},
+		{name: "allowed value in the wrong case", args: []string{"--language=pt_br"}, expectedSuggestion: "--language=pt_BR"},
+		{name: "unrelated option", args: []string{"--everything"}},
+		{name: "unrelated flag", args: []string{"-z"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := parseArgs(parserTestArguments, parserTestOptions, test.args)
+			usageErr, isUsageError := err.(*usageError)
+			if !isUsageError {
+				t.Fatalf("expected a usage error, got: %v", err)
+			}
+			if usageErr.suggestion != test.expectedSuggestion {
+				t.Errorf("expected suggestion %q, got: %q", test.expectedSuggestion, usageErr.suggestion)
+			}
+		})
+	}
+}
@@ -1,12 +1,37 @@
 package dispatcher
 
-import "github.com/yusadeol/go-commit/internal/domain/vo"
+import (
+	"encoding/json"
 
+	"github.com/yusadeol/go-commit/internal/domain/vo"
+)
+
+// Result is what a command exits with. Payload holds its structured output,
+// printed along with the message by --output=json.
 type Result struct {
 	ExitCode vo.ExitCode
 	Message  *vo.MarkupText
+	Payload  any
 }
 
 func NewResult() *Result {
 	return &Result{ExitCode: vo.ExitCodeSuccess, Message: vo.NewMarkupText("")}
 }
+
+// ToJSON renders the result for scripts and editor plugins, with the message
+// stripped of its markup.
+func (r *Result) ToJSON() (string, error) {
+	data, err := json.MarshalIndent(struct {
+		ExitCode vo.ExitCode `json:"exit_code"`
+		Message  string      `json:"message"`
+		Payload  any         `json:"payload,omitempty"`
+	}{
+		ExitCode: r.ExitCode,
+		Message:  r.Message.StripMarkup(),
+		Payload:  r.Payload,
+	}, "", "    ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
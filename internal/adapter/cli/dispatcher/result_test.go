@@ -0,0 +1,35 @@
+package dispatcher
+
+import (
+	"testing"
+
+	"github.com/yusadeol/go-commit/internal/domain/vo"
+)
+
+func TestResultToJSON(t *testing.T) {
+	t.Run("renders the message without markup and the payload", func(t *testing.T) {
+		result := NewResult()
+		result.Message = vo.NewMarkupText("<success>1.2.0</success>")
+		result.Payload = map[string]string{"version": "1.2.0"}
+		output, err := result.ToJSON()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := "{\n    \"exit_code\": 0,\n    \"message\": \"1.2.0\",\n    \"payload\": {\n        \"version\": \"1.2.0\"\n    }\n}"
+		if output != expected {
+			t.Errorf("expected %q, got: %q", expected, output)
+		}
+	})
+
+	t.Run("omits a missing payload", func(t *testing.T) {
+		result := &Result{ExitCode: vo.ExitCodeError, Message: vo.NewMarkupText("<error>failed</error>")}
+		output, err := result.ToJSON()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := "{\n    \"exit_code\": 1,\n    \"message\": \"failed\"\n}"
+		if output != expected {
+			t.Errorf("expected %q, got: %q", expected, output)
+		}
+	})
+}
@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/yusadeol/go-commit/internal/adapter/cli/dispatcher"
+)
+
+var globalOptions = []dispatcher.Option{
+	{
+		Name:        "config",
+		Description: "Configuration file path",
+	},
+	{
+		Name:        "profile",
+		Description: "Configuration profile to apply",
+	},
+	{
+		Name:          "output",
+		Description:   "Output format, json printing the message and the payload of the command",
+		AllowedValues: []string{OutputText, OutputJSON},
+		Default:       OutputText,
+	},
+	{
+		Name:          "color",
+		Description:   "When to color the output",
+		AllowedValues: []string{ColorAuto, ColorAlways, ColorNever},
+		Default:       ColorAuto,
+	},
+}
+
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+)
+
+type GlobalOptions struct {
+	Config  string
+	Profile string
+	Output  string
+	Color   string
+}
+
+// ParseGlobalOptions removes the global options from args, wherever they
+// appear before the -- terminator, so they can be used before any command is
+// dispatched. The words of the hidden completion command are still being
+// typed, so they are passed through unchanged to be completed instead.
+func ParseGlobalOptions(args []string) (*GlobalOptions, []string, error) {
+	if len(args) > 0 && args[0] == dispatcher.CompleteCommandName {
+		return parseCompletionGlobalOptions(args[1:]), args, nil
+	}
+	values := map[string]string{}
+	remainingArgs := make([]string, 0, len(args))
+	for index := 0; index < len(args); index++ {
+		arg := args[index]
+		if arg == "--" {
+			remainingArgs = append(remainingArgs, args[index:]...)
+			break
+		}
+		option, value, hasValue, isGlobalOption := matchGlobalOption(arg)
+		if !isGlobalOption {
+			remainingArgs = append(remainingArgs, arg)
+			continue
+		}
+		if !hasValue {
+			if index+1 >= len(args) {
+				return nil, nil, fmt.Errorf("missing value for option: %s", option.Name)
+			}
+			index++
+			value = args[index]
+		}
+		if len(option.AllowedValues) > 0 && !slices.Contains(option.AllowedValues, value) {
+			return nil, nil, fmt.Errorf(
+				"invalid value for option %q: %q. Allowed values are: %s", option.Name, value, strings.Join(option.AllowedValues, ", "),
+			)
+		}
+		values[option.Name] = value
+	}
+	for _, option := range globalOptions {
+		if _, exists := values[option.Name]; !exists {
+			values[option.Name] = option.Default
+		}
+	}
+	return &GlobalOptions{Config: values["config"], Profile: values["profile"], Output: values["output"], Color: values["color"]}, remainingArgs, nil
+}
+
+// parseCompletionGlobalOptions never fails, as any word may be incomplete.
+// Only the configuration file and the profile are taken from the words, so
+// the values from the configuration can be completed, and the candidates are
+// always printed as plain text. The last word is the one being completed.
+func parseCompletionGlobalOptions(words []string) *GlobalOptions {
+	globalOptions := &GlobalOptions{Output: OutputText, Color: ColorNever}
+	if len(words) > 0 {
+		words = words[:len(words)-1]
+	}
+	for index := 0; index < len(words) && words[index] != "--"; index++ {
+		option, value, hasValue, isGlobalOption := matchGlobalOption(words[index])
+		if !isGlobalOption {
+			continue
+		}
+		if !hasValue {
+			if index+1 >= len(words) {
+				break
+			}
+			index++
+			value = words[index]
+		}
+		switch option.Name {
+		case "config":
+			globalOptions.Config = value
+		case "profile":
+			globalOptions.Profile = value
+		}
+	}
+	return globalOptions
+}
+
+func matchGlobalOption(arg string) (dispatcher.Option, string, bool, bool) {
+	if !strings.HasPrefix(arg, "--") {
+		return dispatcher.Option{}, "", false, false
+	}
+	name, value, hasValue := strings.Cut(strings.TrimPrefix(arg, "--"), "=")
+	for _, option := range globalOptions {
+		if option.Name == name {
+			return option, value, hasValue, true
+		}
+	}
+	return dispatcher.Option{}, "", false, false
+}
@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseGlobalOptions(t *testing.T) {
+	tests := []struct {
+		name            string
+		args            []string
+		expectedConfig  string
+		expectedProfile string
+		expectedArgs    []string
+	}{
+		{name: "without global options", args: []string{"generate", "--commit", "false"}, expectedArgs: []string{"generate", "--commit", "false"}},
+		{name: "separated value", args: []string{"--config", "team.json", "generate"}, expectedConfig: "team.json", expectedArgs: []string{"generate"}},
+		{name: "inline value", args: []string{"generate", "--config=team.json"}, expectedConfig: "team.json", expectedArgs: []string{"generate"}},
+		{name: "after the terminator", args: []string{"generate", "--", "--config=team.json"}, expectedArgs: []string{"generate", "--", "--config=team.json"}},
+		{name: "profile", args: []string{"--profile", "work", "generate", "-p", "openai"}, expectedProfile: "work", expectedArgs: []string{"generate", "-p", "openai"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			globalOptions, args, err := ParseGlobalOptions(test.args)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if globalOptions.Config != test.expectedConfig {
+				t.Errorf("expected config %q, got: %q", test.expectedConfig, globalOptions.Config)
+			}
+			if globalOptions.Profile != test.expectedProfile {
+				t.Errorf("expected profile %q, got: %q", test.expectedProfile, globalOptions.Profile)
+			}
+			if !slices.Equal(args, test.expectedArgs) {
+				t.Errorf("expected args %v, got: %v", test.expectedArgs, args)
+			}
+		})
+	}
+
+	t.Run("defaults to the text output", func(t *testing.T) {
+		globalOptions, _, err := ParseGlobalOptions([]string{"generate"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if globalOptions.Output != OutputText {
+			t.Errorf("expected output %q, got: %q", OutputText, globalOptions.Output)
+		}
+		globalOptions, args, err := ParseGlobalOptions([]string{"generate", "--output=json"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if globalOptions.Output != OutputJSON || !slices.Equal(args, []string{"generate"}) {
+			t.Errorf("expected output %q and args [generate], got: %q and %v", OutputJSON, globalOptions.Output, args)
+		}
+	})
+
+	t.Run("passes the words to complete through unchanged", func(t *testing.T) {
+		tests := [][]string{
+			{"__complete", "--color", ""},
+			{"__complete", "--output="},
+			{"__complete", "--output", "j"},
+			{"__complete", "--config"},
+		}
+		for _, test := range tests {
+			globalOptions, args, err := ParseGlobalOptions(test)
+			if err != nil {
+				t.Fatalf("unexpected error for %v: %v", test, err)
+			}
+			if !slices.Equal(args, test) {
+				t.Errorf("expected args %v, got: %v", test, args)
+			}
+			if globalOptions.Output != OutputText || globalOptions.Color != ColorNever {
+				t.Errorf("expected plain text output, got: %q and %q", globalOptions.Output, globalOptions.Color)
+			}
+		}
+	})
+
+	t.Run("loads the configuration named in the words to complete", func(t *testing.T) {
+		globalOptions, _, err := ParseGlobalOptions([]string{"__complete", "--config", "team.json", "--profile=work", "--output=json", "generate", "--language="})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if globalOptions.Config != "team.json" || globalOptions.Profile != "work" || globalOptions.Output != OutputText {
+			t.Errorf("expected config team.json, profile work and text output, got: %+v", globalOptions)
+		}
+	})
+
+	t.Run("returns error when the value is not allowed", func(t *testing.T) {
+		_, _, err := ParseGlobalOptions([]string{"generate", "--output", "yaml"})
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+
+	t.Run("returns error when the value is missing", func(t *testing.T) {
+		_, _, err := ParseGlobalOptions([]string{"generate", "--config"})
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/yusadeol/go-commit/internal/infra/service/git"
+)
+
+type ApplyCommitSplit struct{}
+
+func NewApplyCommitSplit() *ApplyCommitSplit {
+	return &ApplyCommitSplit{}
+}
+
+// Execute commits each group from a snapshot of the index. When any step
+// fails, HEAD and the index are restored to their original state, which on a
+// branch without commits means removing the branch created by the split.
+func (a *ApplyCommitSplit) Execute(input *ApplyCommitSplitInput) error {
+	head, err := input.Git.HeadCommit()
+	if err != nil {
+		return err
+	}
+	stagedTree, err := input.Git.WriteTree()
+	if err != nil {
+		return err
+	}
+	err = a.commitGroups(input, head, stagedTree)
+	if err == nil {
+		return nil
+	}
+	rollbackErr := a.rollback(input, head, stagedTree)
+	if rollbackErr != nil {
+		return fmt.Errorf("split failed: %w; rollback failed: %v", err, rollbackErr)
+	}
+	return fmt.Errorf("split failed and was rolled back: %w", err)
+}
+
+func (a *ApplyCommitSplit) rollback(input *ApplyCommitSplitInput, head string, stagedTree string) error {
+	var err error
+	if head == "" {
+		headCommit, headErr := input.Git.HeadCommit()
+		if headErr == nil && headCommit != "" {
+			err = input.Git.DeleteRef("HEAD")
+		}
+	} else {
+		err = input.Git.ResetSoft(head)
+	}
+	return errors.Join(err, input.Git.ReadTree(stagedTree))
+}
+
+func (a *ApplyCommitSplit) commitGroups(input *ApplyCommitSplitInput, head string, stagedTree string) error {
+	var err error
+	if head == "" {
+		err = input.Git.ClearIndex()
+	} else {
+		err = input.Git.ResetIndex(head)
+	}
+	if err != nil {
+		return err
+	}
+	for _, group := range input.Groups {
+		err = input.Git.ResetIndex(stagedTree, group.Files...)
+		if err != nil {
+			return err
+		}
+		err = input.Git.Commit(group.Commit)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type ApplyCommitSplitInput struct {
+	Git    *git.Git
+	Groups []CommitGroup
+}
@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/yusadeol/go-commit/internal/domain/vo"
+	"github.com/yusadeol/go-commit/internal/infra/service/git"
+)
+
+var ErrNoReleasableChanges = errors.New("no releasable changes found")
+
+type BumpVersion struct{}
+
+func NewBumpVersion() *BumpVersion {
+	return &BumpVersion{}
+}
+
+func (b *BumpVersion) Execute(input *BumpVersionInput) (*BumpVersionOutput, error) {
+	tags, err := input.Git.Tags("HEAD")
+	if err != nil {
+		return nil, err
+	}
+	currentVersion := &vo.SemanticVersion{Prefix: "v"}
+	currentVersionTag := ""
+	for _, tag := range tags {
+		version, err := vo.ParseSemanticVersion(tag)
+		if err != nil {
+			continue
+		}
+		if !version.IsPreRelease() && (currentVersionTag == "" || version.Compare(currentVersion) > 0) {
+			currentVersion = version
+			currentVersionTag = tag
+		}
+	}
+	revisionRange := "HEAD"
+	if currentVersionTag != "" {
+		revisionRange = currentVersionTag + "..HEAD"
+	}
+	commits, err := input.Git.Log(revisionRange)
+	if err != nil {
+		return nil, err
+	}
+	bump := b.calculateBump(commits)
+	if bump == vo.VersionBumpNone {
+		return nil, ErrNoReleasableChanges
+	}
+	nextVersion := currentVersion.Bump(bump)
+	if input.PreReleaseChannel != "" {
+		allTags, err := input.Git.Tags("")
+		if err != nil {
+			return nil, err
+		}
+		nextVersion.PreRelease = fmt.Sprintf("%s.%d", input.PreReleaseChannel, b.nextPreReleaseNumber(allTags, nextVersion, input.PreReleaseChannel))
+	}
+	output := &BumpVersionOutput{
+		CurrentVersion: currentVersion.String(),
+		NextVersion:    nextVersion.String(),
+		Bump:           bump,
+	}
+	if input.CreateTag {
+		err = input.Git.CreateAnnotatedTag(output.NextVersion, fmt.Sprintf("Release %s", output.NextVersion))
+		if err != nil {
+			return nil, err
+		}
+		output.Tagged = true
+	}
+	return output, nil
+}
+
+func (b *BumpVersion) calculateBump(commits []git.Commit) vo.VersionBump {
+	bump := vo.VersionBumpNone
+	for _, commit := range commits {
+		conventionalCommit, err := vo.ParseConventionalCommit(commit.Message)
+		if err != nil {
+			continue
+		}
+		commitBump := vo.VersionBumpNone
+		switch {
+		case conventionalCommit.Breaking:
+			commitBump = vo.VersionBumpMajor
+		case conventionalCommit.Type == "feat":
+			commitBump = vo.VersionBumpMinor
+		case conventionalCommit.Type == "fix" || conventionalCommit.Type == "perf":
+			commitBump = vo.VersionBumpPatch
+		}
+		if commitBump > bump {
+			bump = commitBump
+		}
+	}
+	return bump
+}
+
+func (b *BumpVersion) nextPreReleaseNumber(tags []string, nextVersion *vo.SemanticVersion, channel string) int {
+	number := 0
+	for _, tag := range tags {
+		version, err := vo.ParseSemanticVersion(tag)
+		if err != nil || !version.SameRelease(nextVersion) {
+			continue
+		}
+		versionChannel, versionNumber := version.PreReleaseChannel()
+		if versionChannel == channel && versionNumber > number {
+			number = versionNumber
+		}
+	}
+	return number + 1
+}
+
+type BumpVersionInput struct {
+	Git               *git.Git
+	PreReleaseChannel string
+	CreateTag         bool
+}
+
+type BumpVersionOutput struct {
+	CurrentVersion string
+	NextVersion    string
+	Bump           vo.VersionBump
+	Tagged         bool
+}
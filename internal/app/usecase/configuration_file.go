@@ -0,0 +1,189 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/yusadeol/go-commit/internal/domain/vo"
+)
+
+var ErrConfigurationKeyNotFound = errors.New("configuration key not found")
+
+// readConfigurationDocument reads the configuration file as a generic JSON
+// document, so keys can be addressed by their dotted path.
+func readConfigurationDocument(configurationFilePath string) (map[string]any, error) {
+	document, _, err := readConfigurationFile(configurationFilePath)
+	return document, err
+}
+
+// decodeConfigurationDocument rejects unknown keys and values of the wrong
+// type by decoding the document into the configuration.
+func decodeConfigurationDocument(document map[string]any) (*vo.Configuration, error) {
+	data, err := json.Marshal(document)
+	if err != nil {
+		return nil, err
+	}
+	var configuration vo.Configuration
+	err = decodeStrictly(data, &configuration)
+	if err != nil {
+		return nil, err
+	}
+	return &configuration, nil
+}
+
+// writeConfigurationFile replaces the file atomically by renaming a fully
+// written temporary file over it.
+func writeConfigurationFile(configurationFilePath string, data []byte, mode os.FileMode) error {
+	file, err := os.CreateTemp(filepath.Dir(configurationFilePath), "."+filepath.Base(configurationFilePath)+".*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(file.Name())
+	}()
+	_, err = file.Write(data)
+	if err == nil {
+		err = file.Sync()
+	}
+	if err == nil {
+		err = file.Chmod(mode)
+	}
+	closeErr := file.Close()
+	if err != nil {
+		return err
+	}
+	if closeErr != nil {
+		return closeErr
+	}
+	return os.Rename(file.Name(), configurationFilePath)
+}
+
+// configurationFileMode keeps the permissions of an existing file, but makes
+// it readable only by the user when the document holds an API key.
+func configurationFileMode(configurationFilePath string, document map[string]any) os.FileMode {
+	mode := os.FileMode(0644)
+	fileInfo, err := os.Stat(configurationFilePath)
+	if err == nil {
+		mode = fileInfo.Mode().Perm()
+	}
+	if hasAPIKey(document) {
+		mode &= 0600
+	}
+	return mode
+}
+
+// hasAPIKey reports whether any api_key in the document is set, including
+// the ones nested in profiles.
+func hasAPIKey(value any) bool {
+	object, isObject := value.(map[string]any)
+	if !isObject {
+		return false
+	}
+	for key, item := range object {
+		if text, isText := item.(string); key == "api_key" && isText && text != "" {
+			return true
+		}
+		if hasAPIKey(item) {
+			return true
+		}
+	}
+	return false
+}
+
+// writeConfigurationDocument replaces the file with the document, backing up
+// files written by an older release first. It returns the path of the
+// backup, if any.
+func writeConfigurationDocument(configurationFilePath string, document map[string]any) (string, error) {
+	data, err := encodeConfigurationDocument(configurationFilePath, document)
+	if err != nil {
+		return "", err
+	}
+	backupFilePath, err := backupOutdatedConfigurationFile(configurationFilePath, document)
+	if err != nil {
+		return "", err
+	}
+	return backupFilePath, writeConfigurationFile(configurationFilePath, data, configurationFileMode(configurationFilePath, document))
+}
+
+func getDocumentValue(document map[string]any, key string) (any, error) {
+	var value any = document
+	for _, part := range strings.Split(key, ".") {
+		object, isObject := value.(map[string]any)
+		if !isObject {
+			return nil, fmt.Errorf("%w: %s", ErrConfigurationKeyNotFound, key)
+		}
+		value, isObject = object[part]
+		if !isObject {
+			return nil, fmt.Errorf("%w: %s", ErrConfigurationKeyNotFound, key)
+		}
+	}
+	return value, nil
+}
+
+// setDocumentValue sets the value at key, creating the intermediate objects.
+// A nil value removes the key.
+func setDocumentValue(document map[string]any, key string, value any) error {
+	parts := strings.Split(key, ".")
+	object := document
+	for _, part := range parts[:len(parts)-1] {
+		child, exists := object[part]
+		if !exists && value != nil {
+			child = map[string]any{}
+			object[part] = child
+		}
+		childObject, isObject := child.(map[string]any)
+		if !isObject {
+			return fmt.Errorf("%w: %s", ErrConfigurationKeyNotFound, key)
+		}
+		object = childObject
+	}
+	lastPart := parts[len(parts)-1]
+	if value == nil {
+		if _, exists := object[lastPart]; !exists {
+			return fmt.Errorf("%w: %s", ErrConfigurationKeyNotFound, key)
+		}
+		delete(object, lastPart)
+		return nil
+	}
+	object[lastPart] = value
+	return nil
+}
+
+// flattenDocument returns every leaf of the document keyed by its dotted path.
+func flattenDocument(prefix string, value any, values map[string]any) {
+	object, isObject := value.(map[string]any)
+	if !isObject || (len(object) == 0 && prefix != "") {
+		values[prefix] = value
+		return
+	}
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		flattenDocument(path, object[key], values)
+	}
+}
+
+// FormatConfigurationValue prints strings as they are and every other value
+// as JSON.
+func FormatConfigurationValue(value any) string {
+	if text, isText := value.(string); isText {
+		return text
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
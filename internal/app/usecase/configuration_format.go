@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+
+	"github.com/yusadeol/go-commit/internal/domain/vo"
+)
+
+// configurationFileExtensions lists the supported formats in the order they
+// are looked up when no configuration file is given.
+var configurationFileExtensions = []string{".json", ".yaml", ".yml", ".toml"}
+
+// decodeConfigurationData reads YAML, TOML or JSON, chosen by the file
+// extension, into a JSON document, so every format shares the json struct
+// tags of vo.Configuration.
+func decodeConfigurationData(filePath string, data []byte) (map[string]any, error) {
+	var document map[string]any
+	var err error
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &document)
+	case ".toml":
+		err = toml.Unmarshal(data, &document)
+	default:
+		err = json.Unmarshal(data, &document)
+		if err != nil {
+			return nil, err
+		}
+		return document, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	jsonData, err := json.Marshal(document)
+	if err != nil {
+		return nil, err
+	}
+	var jsonDocument map[string]any
+	err = json.Unmarshal(jsonData, &jsonDocument)
+	if err != nil {
+		return nil, err
+	}
+	return jsonDocument, nil
+}
+
+// encodeConfigurationDocument writes the document in the format of the file
+// extension.
+func encodeConfigurationDocument(filePath string, document map[string]any) ([]byte, error) {
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".yaml", ".yml":
+		var buffer bytes.Buffer
+		encoder := yaml.NewEncoder(&buffer)
+		encoder.SetIndent(4)
+		err := encoder.Encode(document)
+		if err != nil {
+			return nil, err
+		}
+		return buffer.Bytes(), encoder.Close()
+	case ".toml":
+		var buffer bytes.Buffer
+		err := toml.NewEncoder(&buffer).Encode(toTOMLValue(document))
+		return buffer.Bytes(), err
+	default:
+		data, err := json.MarshalIndent(document, "", "    ")
+		return append(data, '\n'), err
+	}
+}
+
+// encodeConfiguration keeps the field order of vo.Configuration for JSON files.
+func encodeConfiguration(filePath string, configuration *vo.Configuration) ([]byte, error) {
+	data, err := json.MarshalIndent(configuration, "", "    ")
+	if err != nil {
+		return nil, err
+	}
+	if !isJSONFile(filePath) {
+		var document map[string]any
+		err = json.Unmarshal(data, &document)
+		if err != nil {
+			return nil, err
+		}
+		return encodeConfigurationDocument(filePath, document)
+	}
+	return append(data, '\n'), nil
+}
+
+func isJSONFile(filePath string) bool {
+	extension := strings.ToLower(filepath.Ext(filePath))
+	return extension != ".yaml" && extension != ".yml" && extension != ".toml"
+}
+
+// FindConfigurationFile returns the first configuration file found in the
+// directory, trying each supported format, or the JSON one when none exists.
+func FindConfigurationFile(dirPath string, baseName string) string {
+	filePath := findExistingConfigurationFile(dirPath, baseName)
+	if filePath == "" {
+		return filepath.Join(dirPath, baseName+".json")
+	}
+	return filePath
+}
+
+func findExistingConfigurationFile(dirPath string, baseName string) string {
+	for _, extension := range configurationFileExtensions {
+		filePath := filepath.Join(dirPath, baseName+extension)
+		_, err := os.Stat(filePath)
+		if err == nil {
+			return filePath
+		}
+	}
+	return ""
+}
+
+// toTOMLValue drops null values, which TOML cannot represent, and turns the
+// whole numbers decoded from JSON back into integers.
+func toTOMLValue(value any) any {
+	switch typedValue := value.(type) {
+	case map[string]any:
+		tomlDocument := map[string]any{}
+		for key, item := range typedValue {
+			if item != nil {
+				tomlDocument[key] = toTOMLValue(item)
+			}
+		}
+		return tomlDocument
+	case []any:
+		tomlList := make([]any, 0, len(typedValue))
+		for _, item := range typedValue {
+			tomlList = append(tomlList, toTOMLValue(item))
+		}
+		return tomlList
+	case float64:
+		if typedValue == math.Trunc(typedValue) {
+			return int64(typedValue)
+		}
+	}
+	return value
+}
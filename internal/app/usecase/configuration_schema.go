@@ -0,0 +1,199 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/yusadeol/go-commit/internal/domain/vo"
+)
+
+const ConfigurationSchemaURL = "https://raw.githubusercontent.com/yusadeol/go-commit/main/schema/commit.schema.json"
+
+// configurationMigrations upgrade a configuration document from the schema
+// version at their index to the next one. A structural change to
+// vo.Configuration must append a migration and bump the schema version.
+var configurationMigrations = []func(document map[string]any) error{
+	migrateConfigurationToVersion1,
+}
+
+// migrateConfigurationToVersion1 fills the IDs of AI providers and languages
+// from their keys, as commands now rely on them.
+func migrateConfigurationToVersion1(document map[string]any) error {
+	for _, key := range []string{"ai_providers", "languages"} {
+		entries, isObject := document[key].(map[string]any)
+		if !isObject {
+			continue
+		}
+		for id, entry := range entries {
+			entryObject, isObject := entry.(map[string]any)
+			if isObject && entryObject["id"] == nil {
+				entryObject["id"] = id
+			}
+		}
+	}
+	return nil
+}
+
+// readMigratedConfigurationFile reads the configuration file and, when it was
+// written by an older release, upgrades it in place after saving a backup
+// next to it. The backup keeps the original as is, including the comments of
+// YAML and TOML files, which the upgraded file loses.
+func readMigratedConfigurationFile(configurationFilePath string) ([]byte, error) {
+	document, schemaVersion, err := readConfigurationFile(configurationFilePath)
+	if err != nil {
+		return nil, err
+	}
+	if schemaVersion < vo.ConfigurationSchemaVersion {
+		_, err = writeConfigurationDocument(configurationFilePath, document)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(document)
+}
+
+// readConfigurationFile returns the document of the configuration file
+// upgraded to the current schema version in memory, along with the version
+// the file was written with.
+func readConfigurationFile(configurationFilePath string) (map[string]any, int, error) {
+	data, err := os.ReadFile(configurationFilePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, 0, fmt.Errorf("%w: %s", vo.ErrConfigurationNotFound, configurationFilePath)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	document, err := decodeConfigurationData(configurationFilePath, data)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid configuration %s: %w", configurationFilePath, err)
+	}
+	schemaVersion, err := getSchemaVersion(configurationFilePath, document)
+	if err != nil {
+		return nil, 0, err
+	}
+	if schemaVersion > vo.ConfigurationSchemaVersion {
+		return nil, 0, fmt.Errorf(
+			"configuration %s uses schema version %d, but this release only supports up to %d. Please upgrade commit",
+			configurationFilePath, schemaVersion, vo.ConfigurationSchemaVersion,
+		)
+	}
+	for _, migrate := range configurationMigrations[schemaVersion:] {
+		err = migrate(document)
+		if err != nil {
+			return nil, 0, fmt.Errorf("could not migrate configuration %s: %w", configurationFilePath, err)
+		}
+	}
+	document["schema_version"] = vo.ConfigurationSchemaVersion
+	return document, schemaVersion, nil
+}
+
+// getSchemaVersion returns the schema_version of the document, which is 0
+// for files written before it was introduced.
+func getSchemaVersion(configurationFilePath string, document map[string]any) (int, error) {
+	value, exists := document["schema_version"]
+	if !exists {
+		return 0, nil
+	}
+	number, isNumber := value.(float64)
+	if !isNumber || number != float64(int(number)) || number < 0 {
+		return 0, fmt.Errorf("invalid configuration %s: schema_version must be a non-negative integer", configurationFilePath)
+	}
+	return int(number), nil
+}
+
+// backupOutdatedConfigurationFile copies a file written by an older release
+// before it is rewritten, naming the copy after the old version and the time
+// so earlier backups are kept. It returns the path of the copy, which is
+// empty when the file needed none.
+func backupOutdatedConfigurationFile(configurationFilePath string, document map[string]any) (string, error) {
+	data, err := os.ReadFile(configurationFilePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	originalDocument, err := decodeConfigurationData(configurationFilePath, data)
+	if err != nil {
+		return "", err
+	}
+	schemaVersion, err := getSchemaVersion(configurationFilePath, originalDocument)
+	if err != nil || schemaVersion >= vo.ConfigurationSchemaVersion {
+		return "", err
+	}
+	backupFilePath := fmt.Sprintf("%s.v%d.%s.bak", configurationFilePath, schemaVersion, time.Now().Format("20060102150405"))
+	err = writeConfigurationFile(backupFilePath, data, configurationFileMode(configurationFilePath, document))
+	if err != nil {
+		return "", err
+	}
+	return backupFilePath, nil
+}
+
+// decodeStrictly decodes data into target, reporting every unknown key along
+// with the closest known one.
+func decodeStrictly(data []byte, target any) error {
+	var document any
+	err := json.Unmarshal(data, &document)
+	if err != nil {
+		return err
+	}
+	err = errors.Join(findUnknownKeys(document, reflect.TypeOf(target), "")...)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, target)
+}
+
+func findUnknownKeys(value any, valueType reflect.Type, path string) []error {
+	for valueType.Kind() == reflect.Pointer {
+		valueType = valueType.Elem()
+	}
+	object, isObject := value.(map[string]any)
+	if !isObject {
+		return nil
+	}
+	var errs []error
+	if valueType.Kind() == reflect.Map {
+		for _, key := range slices.Sorted(maps.Keys(object)) {
+			errs = append(errs, findUnknownKeys(object[key], valueType.Elem(), joinKey(path, key))...)
+		}
+		return errs
+	}
+	if valueType.Kind() != reflect.Struct {
+		return nil
+	}
+	fields := map[string]reflect.Type{}
+	for i := range valueType.NumField() {
+		name, _, _ := strings.Cut(valueType.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			fields[name] = valueType.Field(i).Type
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(object)) {
+		fieldType, exists := fields[key]
+		if exists {
+			errs = append(errs, findUnknownKeys(object[key], fieldType, joinKey(path, key))...)
+			continue
+		}
+		err := fmt.Errorf("unknown key %q", joinKey(path, key))
+		if suggestion := vo.Suggest(key, slices.Sorted(maps.Keys(fields))); suggestion != "" {
+			err = fmt.Errorf("%w, did you mean %q?", err, joinKey(path, suggestion))
+		}
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+func joinKey(path string, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
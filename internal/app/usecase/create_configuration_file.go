@@ -1,44 +1,24 @@
 package usecase
 
 import (
-	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 
 	"github.com/yusadeol/go-commit/internal/domain/vo"
+	"github.com/yusadeol/go-commit/internal/infra/service/credential"
 )
 
 var ErrConfigurationAlreadyExists = errors.New("configuration already exists")
 
-var defaultConfiguration = vo.Configuration{
-	DefaultAIProvider: "openai",
-	DefaultLanguage:   "en_US",
-	AIProviders: map[string]vo.AIProvider{
-		"openai": {
-			ID:     "openai",
-			APIKey: "",
-			Models: []string{
-				"gpt-4.1",
-			},
-			DefaultModel: "gpt-4.1",
-		},
-	},
-	Languages: map[string]vo.Language{
-		"en_US": {
-			ID:          "en_US",
-			DisplayName: "English (United States)",
-		},
-		"pt_BR": {
-			ID:          "pt_BR",
-			DisplayName: "Portuguese (Brazil)",
-		},
-		"es_ES": {
-			ID:          "es_ES",
-			DisplayName: "Spanish (Spain)",
-		},
-	},
-}
+const (
+	APIKeySourceEnv       = "env"
+	APIKeySourceCommand   = "command"
+	APIKeySourceKeyring   = "keyring"
+	APIKeySourcePlaintext = "plaintext"
+)
 
 type CreateConfigurationFile struct{}
 
@@ -46,27 +26,96 @@ func NewCreateConfigurationFile() *CreateConfigurationFile {
 	return &CreateConfigurationFile{}
 }
 
+// Execute writes the default configuration with the given choices applied.
+// Missing directories are created only readable by the user, and the file is
+// kept private when it holds an API key.
 func (c *CreateConfigurationFile) Execute(input *CreateConfigurationFileInput) error {
-	_, err := os.Stat(input.ConfigurationDirPath)
+	_, err := os.Stat(input.ConfigurationFilePath)
+	if err == nil && !input.Force {
+		return ErrConfigurationAlreadyExists
+	}
+	configuration := NewDefaultConfiguration()
+	err = c.applyChoices(configuration, input)
 	if err != nil {
 		return err
 	}
-	configurationMarshal, err := json.MarshalIndent(defaultConfiguration, "", "    ")
+	err = configuration.Validate()
 	if err != nil {
 		return err
 	}
-	configurationFilePath := filepath.Join(input.ConfigurationDirPath, "commit.json")
-	_, err = os.Stat(configurationFilePath)
-	if err == nil {
-		return ErrConfigurationAlreadyExists
+	configurationMarshal, err := encodeConfiguration(input.ConfigurationFilePath, configuration)
+	if err != nil {
+		return err
 	}
-	err = os.WriteFile(configurationFilePath, configurationMarshal, 0644)
+	err = os.MkdirAll(filepath.Dir(input.ConfigurationFilePath), 0700)
 	if err != nil {
 		return err
 	}
+	mode := os.FileMode(0644)
+	if input.APIKeySource == APIKeySourcePlaintext && input.APIKey != "" {
+		mode = 0600
+	}
+	if input.APIKeySource == APIKeySourceKeyring && input.APIKey != "" {
+		err = input.Keyring.Set(configuration.DefaultAIProvider, input.APIKey)
+		if err != nil {
+			return err
+		}
+	}
+	return writeConfigurationFile(input.ConfigurationFilePath, configurationMarshal, mode)
+}
+
+func (c *CreateConfigurationFile) applyChoices(configuration *vo.Configuration, input *CreateConfigurationFileInput) error {
+	if input.AIProvider != "" {
+		configuration.DefaultAIProvider = input.AIProvider
+	}
+	if input.Language != "" {
+		configuration.DefaultLanguage = input.Language
+	}
+	aiProvider, exists := configuration.AIProviders[configuration.DefaultAIProvider]
+	if !exists {
+		return fmt.Errorf("AI provider %q is not supported", configuration.DefaultAIProvider)
+	}
+	if input.Model != "" {
+		if !slices.Contains(aiProvider.Models, input.Model) {
+			aiProvider.Models = append(aiProvider.Models, input.Model)
+		}
+		aiProvider.DefaultModel = input.Model
+	}
+	switch input.APIKeySource {
+	case "":
+	case APIKeySourceEnv:
+		aiProvider.APIKeyEnv = input.APIKey
+	case APIKeySourceCommand:
+		aiProvider.APIKeyCommand = input.APIKey
+	case APIKeySourceKeyring:
+		aiProvider.APIKeyKeyring = true
+	case APIKeySourcePlaintext:
+		aiProvider.APIKey = input.APIKey
+	default:
+		return fmt.Errorf("API key source %q is not supported", input.APIKeySource)
+	}
+	configuration.AIProviders[configuration.DefaultAIProvider] = aiProvider
+	if input.HistoryExamples != nil {
+		configuration.HistoryExamples.Enabled = *input.HistoryExamples
+	}
+	if input.TicketPlacement != "" {
+		configuration.Ticket.Placement = input.TicketPlacement
+	}
 	return nil
 }
 
+// CreateConfigurationFileInput holds the init choices. APIKey is the key
+// itself for the plaintext and keyring sources, the variable name for env and
+// the command line for command.
 type CreateConfigurationFileInput struct {
-	ConfigurationDirPath string
+	ConfigurationFilePath string
+	Force                 bool
+	AIProvider            string
+	Language              string
+	Model                 string
+	APIKeySource          string
+	APIKey                string
+	HistoryExamples       *bool
+	TicketPlacement       string
+	Keyring               credential.Keyring
 }
@@ -0,0 +1,48 @@
+package usecase
+
+import "github.com/yusadeol/go-commit/internal/domain/vo"
+
+// NewDefaultConfiguration returns the configuration written by init and used
+// as the first layer when loading, with maps that are safe to modify.
+func NewDefaultConfiguration() *vo.Configuration {
+	return &vo.Configuration{
+		Schema:            ConfigurationSchemaURL,
+		SchemaVersion:     vo.ConfigurationSchemaVersion,
+		DefaultAIProvider: "openai",
+		DefaultLanguage:   "en_US",
+		AIProviders: map[string]vo.AIProvider{
+			"openai": {
+				ID:     "openai",
+				APIKey: "",
+				Models: []string{
+					"gpt-4.1",
+				},
+				DefaultModel: "gpt-4.1",
+			},
+		},
+		Languages: map[string]vo.Language{
+			"en_US": {
+				ID:          "en_US",
+				DisplayName: "English (United States)",
+			},
+			"pt_BR": {
+				ID:          "pt_BR",
+				DisplayName: "Portuguese (Brazil)",
+			},
+			"es_ES": {
+				ID:          "es_ES",
+				DisplayName: "Spanish (Spain)",
+			},
+		},
+		BranchPattern: vo.DefaultBranchPattern,
+		Ticket: vo.Ticket{
+			Pattern:     "[A-Z][A-Z0-9]+-[0-9]+",
+			Placement:   vo.TicketPlacementFooter,
+			FooterToken: "Refs",
+		},
+		HistoryExamples: vo.HistoryExamples{
+			Enabled: true,
+			Count:   5,
+		},
+	}
+}
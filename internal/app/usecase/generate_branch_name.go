@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"fmt"
+
+	"github.com/yusadeol/go-commit/internal/domain/vo"
+	"github.com/yusadeol/go-commit/internal/infra/service/ai"
+)
+
+type GenerateBranchName struct{}
+
+func NewGenerateBranchName() *GenerateBranchName {
+	return &GenerateBranchName{}
+}
+
+func (g *GenerateBranchName) Execute(input *GenerateBranchNameInput) (*GenerateBranchNameOutput, error) {
+	aiProvider, err := input.AIDefaultProviderFactory.Create(input.AIProvider.ID, input.AIProvider.APIKey)
+	if err != nil {
+		return nil, err
+	}
+	instructions := `
+		Summarize this change as a single Conventional Commits header, such as "feat: add login page".
+		Do NOT use scopes.
+		The description must have at most 6 words.
+		Write it in English language without any accents.
+		ONLY return the header, without any additional text or explanation.
+	`
+	changeInput := input.Diff
+	if input.Description != "" {
+		changeInput = input.Description
+	}
+	output, err := aiProvider.Ask(&ai.ProviderInput{
+		Model:        input.AIProvider.DefaultModel,
+		Instructions: instructions,
+		Input:        changeInput,
+	})
+	if err != nil {
+		return nil, err
+	}
+	conventionalCommit, err := vo.ParseConventionalCommit(output.Text)
+	if err != nil {
+		return nil, fmt.Errorf("AI provider returned an invalid header %q: %w", output.Text, err)
+	}
+	ticket := input.Ticket
+	if ticket == "" && input.TicketConfiguration != nil {
+		ticket, err = input.TicketConfiguration.Extract(input.Description)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &GenerateBranchNameOutput{
+		BranchName: vo.NewBranchName(input.Pattern, conventionalCommit.Type, ticket, conventionalCommit.Description),
+	}, nil
+}
+
+type GenerateBranchNameInput struct {
+	AIDefaultProviderFactory ai.ProviderFactory
+	AIProvider               *vo.AIProvider
+	Description              string
+	Diff                     string
+	Ticket                   string
+	TicketConfiguration      *vo.Ticket
+	Pattern                  string
+}
+
+type GenerateBranchNameOutput struct {
+	BranchName string
+}
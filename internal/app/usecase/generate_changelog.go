@@ -0,0 +1,215 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/yusadeol/go-commit/internal/domain/vo"
+	"github.com/yusadeol/go-commit/internal/infra/service/ai"
+	"github.com/yusadeol/go-commit/internal/infra/service/git"
+)
+
+var ErrNoChangelogEntries = errors.New("no changelog entries found")
+
+const changelogHeader = `# Changelog
+
+All notable changes to this project will be documented in this file.
+
+The format is based on [Keep a Changelog](https://keepachangelog.com/en/1.1.0/),
+and this project adheres to [Conventional Commits](https://www.conventionalcommits.org/en/v1.0.0/).
+`
+
+var changelogSections = []struct {
+	Title string
+	Types []string
+}{
+	{Title: "Added", Types: []string{"feat"}},
+	{Title: "Fixed", Types: []string{"fix"}},
+	{Title: "Changed", Types: []string{"perf", "refactor"}},
+	{Title: "Removed", Types: []string{"revert"}},
+	{Title: "Documentation", Types: []string{"docs"}},
+	{Title: "Maintenance", Types: []string{"build", "ci", "chore", "style", "test"}},
+}
+
+type GenerateChangelog struct{}
+
+func NewGenerateChangelog() *GenerateChangelog {
+	return &GenerateChangelog{}
+}
+
+func (g *GenerateChangelog) Execute(input *GenerateChangelogInput) (*GenerateChangelogOutput, error) {
+	release, err := g.renderRelease(input)
+	if err != nil {
+		return nil, err
+	}
+	if input.AIProvider != nil {
+		release, err = g.polishRelease(input, release)
+		if err != nil {
+			return nil, err
+		}
+	}
+	existingChangelog, err := os.ReadFile(input.ChangelogFilePath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	changelog := g.mergeRelease(string(existingChangelog), input.Version, release)
+	err = os.WriteFile(input.ChangelogFilePath, []byte(changelog), 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &GenerateChangelogOutput{Release: release}, nil
+}
+
+func (g *GenerateChangelog) renderRelease(input *GenerateChangelogInput) (string, error) {
+	entriesByType := map[string][]changelogEntry{}
+	var breakingChanges []changelogEntry
+	for _, commit := range input.Commits {
+		conventionalCommit, err := vo.ParseConventionalCommit(commit.Message)
+		if err != nil {
+			continue
+		}
+		entry := changelogEntry{hash: commit.Hash, commit: conventionalCommit}
+		entriesByType[conventionalCommit.Type] = append(entriesByType[conventionalCommit.Type], entry)
+		if conventionalCommit.Breaking {
+			breakingChanges = append(breakingChanges, entry)
+		}
+	}
+	var sections []string
+	if len(breakingChanges) > 0 {
+		sections = append(sections, g.renderSection("BREAKING CHANGES", breakingChanges, true))
+	}
+	for _, section := range changelogSections {
+		var entries []changelogEntry
+		for _, commitType := range section.Types {
+			entries = append(entries, entriesByType[commitType]...)
+		}
+		if len(entries) == 0 {
+			continue
+		}
+		sections = append(sections, g.renderSection(section.Title, entries, false))
+	}
+	if len(sections) == 0 {
+		return "", ErrNoChangelogEntries
+	}
+	heading := fmt.Sprintf("## [%s]", input.Version)
+	if input.Date != "" {
+		heading += " - " + input.Date
+	}
+	return heading + "\n\n" + strings.Join(sections, "\n"), nil
+}
+
+func (g *GenerateChangelog) renderSection(title string, entries []changelogEntry, breaking bool) string {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].commit.Scope < entries[j].commit.Scope
+	})
+	lines := []string{fmt.Sprintf("### %s", title), ""}
+	for _, entry := range entries {
+		description := entry.commit.Description
+		if breaking {
+			description = entry.commit.BreakingChange()
+		} else if entry.commit.Breaking {
+			description = "**BREAKING** " + description
+		}
+		line := "- "
+		if entry.commit.Scope != "" {
+			line += fmt.Sprintf("**%s:** ", entry.commit.Scope)
+		}
+		line += strings.ReplaceAll(description, "\n", " ")
+		if len(entry.hash) >= 7 {
+			line += fmt.Sprintf(" (%s)", entry.hash[:7])
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func (g *GenerateChangelog) polishRelease(input *GenerateChangelogInput, release string) (string, error) {
+	aiProvider, err := input.AIDefaultProviderFactory.Create(input.AIProvider.ID, input.AIProvider.APIKey)
+	if err != nil {
+		return "", err
+	}
+	instructions := fmt.Sprintf(`
+		Polish the wording of this Markdown changelog section so it reads well for end users.
+		Write it in %s language.
+		Keep EVERY heading, scope and commit hash exactly as they are.
+		Do NOT add, remove or merge list items.
+		ONLY return the Markdown, without any additional text or explanation.
+	`, input.Language.DisplayName)
+	output, err := aiProvider.Ask(&ai.ProviderInput{
+		Model:        input.AIProvider.DefaultModel,
+		Instructions: instructions,
+		Input:        release,
+	})
+	if err != nil {
+		return "", err
+	}
+	polishedRelease := strings.TrimSpace(output.Text)
+	if polishedRelease == "" {
+		return release, nil
+	}
+	return polishedRelease + "\n", nil
+}
+
+// mergeRelease replaces the section of the same version when it already
+// exists, otherwise it inserts the release above the most recent one.
+func (g *GenerateChangelog) mergeRelease(changelog string, version string, release string) string {
+	if strings.TrimSpace(changelog) == "" {
+		return changelogHeader + "\n" + release
+	}
+	lines := strings.SplitAfter(changelog, "\n")
+	versionHeading := fmt.Sprintf("## [%s]", version)
+	firstReleaseStart := -1
+	releaseStart := -1
+	for index, line := range lines {
+		if !strings.HasPrefix(line, "## ") {
+			continue
+		}
+		if firstReleaseStart == -1 {
+			firstReleaseStart = index
+		}
+		if strings.HasPrefix(line, versionHeading) {
+			releaseStart = index
+			break
+		}
+	}
+	if releaseStart == -1 {
+		if firstReleaseStart == -1 {
+			return strings.TrimRight(changelog, "\n") + "\n\n" + release
+		}
+		return strings.Join(lines[:firstReleaseStart], "") + release + "\n" + strings.Join(lines[firstReleaseStart:], "")
+	}
+	releaseEnd := len(lines)
+	for index := releaseStart + 1; index < len(lines); index++ {
+		if strings.HasPrefix(lines[index], "## ") {
+			releaseEnd = index
+			break
+		}
+	}
+	merged := strings.Join(lines[:releaseStart], "") + release
+	if releaseEnd < len(lines) {
+		merged += "\n" + strings.Join(lines[releaseEnd:], "")
+	}
+	return merged
+}
+
+type changelogEntry struct {
+	hash   string
+	commit *vo.ConventionalCommit
+}
+
+type GenerateChangelogInput struct {
+	AIDefaultProviderFactory ai.ProviderFactory
+	AIProvider               *vo.AIProvider
+	Language                 *vo.Language
+	Commits                  []git.Commit
+	Version                  string
+	Date                     string
+	ChangelogFilePath        string
+}
+
+type GenerateChangelogOutput struct {
+	Release string
+}
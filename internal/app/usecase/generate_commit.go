@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/yusadeol/go-commit/internal/domain/vo"
 	"github.com/yusadeol/go-commit/internal/infra/service/ai"
@@ -18,9 +19,13 @@ func (g *Generate) Execute(input *GenerateInput) (*GenerateOutput, error) {
 	if err != nil {
 		return nil, err
 	}
+	scopesInstruction := "Do NOT use scopes."
+	if len(input.Scopes) > 0 {
+		scopesInstruction = fmt.Sprintf("Use a scope ONLY from this list: %s.", strings.Join(input.Scopes, ", "))
+	}
 	instructions := fmt.Sprintf(`
         Write a commit message for this diff following Conventional Commits specification.
-		Do NOT use scopes.
+		%s
 		EACH line must not exceed 72 characters.
 		Write the commit message in %s language without any accents.
 		ONLY return the commit message, without any additional text or explanation.
@@ -31,7 +36,19 @@ func (g *Generate) Execute(input *GenerateInput) (*GenerateOutput, error) {
 
 		- Add a new feature
 		- Fix a bug
-	`, input.Language.DisplayName)
+	`, scopesInstruction, input.Language.DisplayName)
+	if len(input.Types) > 0 {
+		instructions += fmt.Sprintf("\nUse a type ONLY from this list: %s.\n", strings.Join(input.Types, ", "))
+	}
+	if input.Prompt != "" {
+		instructions += "\n" + input.Prompt + "\n"
+	}
+	if len(input.Examples) > 0 {
+		instructions += fmt.Sprintf(
+			"\nFollow the tone and conventions of these recent commits from the same repository:\n\n%s\n",
+			strings.Join(input.Examples, "\n\n---\n\n"),
+		)
+	}
 	output, err := aiProvider.Ask(&ai.ProviderInput{
 		Model:        input.AIProvider.DefaultModel,
 		Instructions: instructions,
@@ -40,7 +57,41 @@ func (g *Generate) Execute(input *GenerateInput) (*GenerateOutput, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &GenerateOutput{Commit: output.Text}, nil
+	commit := output.Text
+	if input.Ticket != nil {
+		ticket, err := input.Ticket.Extract(input.Branch)
+		if err != nil {
+			return nil, err
+		}
+		commit = g.addTicket(commit, ticket, input.Ticket)
+	}
+	return &GenerateOutput{Commit: commit, Usage: output.Usage}, nil
+}
+
+// addTicket enforces the ticket reference on the generated commit instead of
+// trusting the model to follow the instructions.
+func (g *Generate) addTicket(commit string, ticket string, configuration *vo.Ticket) string {
+	if ticket == "" || strings.Contains(commit, ticket) {
+		return commit
+	}
+	conventionalCommit, err := vo.ParseConventionalCommit(commit)
+	if configuration.Placement == vo.TicketPlacementPrefix {
+		if err != nil {
+			return ticket + " " + commit
+		}
+		conventionalCommit.Description = ticket + " " + conventionalCommit.Description
+		return conventionalCommit.String()
+	}
+	footerToken := configuration.FooterToken
+	if footerToken == "" {
+		footerToken = "Refs"
+	}
+	footer := vo.Footer{Token: footerToken, Value: ticket}
+	if err != nil {
+		return strings.TrimSpace(commit) + "\n\n" + footer.String()
+	}
+	conventionalCommit.Footers = append(conventionalCommit.Footers, footer)
+	return conventionalCommit.String()
 }
 
 type GenerateInput struct {
@@ -48,8 +99,15 @@ type GenerateInput struct {
 	AIProvider               *vo.AIProvider
 	Language                 *vo.Language
 	Diff                     string
+	Ticket                   *vo.Ticket
+	Branch                   string
+	Examples                 []string
+	Prompt                   string
+	Types                    []string
+	Scopes                   []string
 }
 
 type GenerateOutput struct {
 	Commit string
+	Usage  ai.TokenUsage
 }
@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yusadeol/go-commit/internal/domain/vo"
+	"github.com/yusadeol/go-commit/internal/infra/service/ai"
+	"github.com/yusadeol/go-commit/internal/infra/service/git"
+)
+
+type GeneratePullRequest struct{}
+
+func NewGeneratePullRequest() *GeneratePullRequest {
+	return &GeneratePullRequest{}
+}
+
+func (g *GeneratePullRequest) Execute(input *GeneratePullRequestInput) (*GeneratePullRequestOutput, error) {
+	aiProvider, err := input.AIDefaultProviderFactory.Create(input.AIProvider.ID, input.AIProvider.APIKey)
+	if err != nil {
+		return nil, err
+	}
+	instructions := fmt.Sprintf(`
+		Write a pull request for these commits and diff.
+		The FIRST line is the pull request title following Conventional Commits specification.
+		The title must not exceed 72 characters.
+		After an empty line, write the description in Markdown with these sections:
+		## Summary
+		## Changes
+		## Testing
+		Write the pull request in %s language.
+		ONLY return the pull request, without any additional text or explanation.
+	`, input.Language.DisplayName)
+	commitMessages := make([]string, 0, len(input.Commits))
+	for _, commit := range input.Commits {
+		commitMessages = append(commitMessages, commit.Message)
+	}
+	output, err := aiProvider.Ask(&ai.ProviderInput{
+		Model:        input.AIProvider.DefaultModel,
+		Instructions: instructions,
+		Input:        fmt.Sprintf("Commits:\n\n%s\n\nDiff:\n\n%s", strings.Join(commitMessages, "\n\n"), input.Diff),
+	})
+	if err != nil {
+		return nil, err
+	}
+	title, body, _ := strings.Cut(strings.TrimSpace(output.Text), "\n")
+	return &GeneratePullRequestOutput{
+		Title: strings.TrimSpace(title),
+		Body:  strings.TrimSpace(body),
+	}, nil
+}
+
+type GeneratePullRequestInput struct {
+	AIDefaultProviderFactory ai.ProviderFactory
+	AIProvider               *vo.AIProvider
+	Language                 *vo.Language
+	Commits                  []git.Commit
+	Diff                     string
+}
+
+type GeneratePullRequestOutput struct {
+	Title string
+	Body  string
+}
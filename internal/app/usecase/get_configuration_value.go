@@ -0,0 +1,28 @@
+package usecase
+
+type GetConfigurationValue struct{}
+
+func NewGetConfigurationValue() *GetConfigurationValue {
+	return &GetConfigurationValue{}
+}
+
+func (g *GetConfigurationValue) Execute(input *GetConfigurationValueInput) (*GetConfigurationValueOutput, error) {
+	document, err := readConfigurationDocument(input.ConfigurationFilePath)
+	if err != nil {
+		return nil, err
+	}
+	value, err := getDocumentValue(document, input.Key)
+	if err != nil {
+		return nil, err
+	}
+	return &GetConfigurationValueOutput{Value: value}, nil
+}
+
+type GetConfigurationValueInput struct {
+	ConfigurationFilePath string
+	Key                   string
+}
+
+type GetConfigurationValueOutput struct {
+	Value any
+}
@@ -0,0 +1,33 @@
+package usecase
+
+import "sort"
+
+type ListConfigurationValues struct{}
+
+func NewListConfigurationValues() *ListConfigurationValues {
+	return &ListConfigurationValues{}
+}
+
+func (l *ListConfigurationValues) Execute(input *ListConfigurationValuesInput) (*ListConfigurationValuesOutput, error) {
+	document, err := readConfigurationDocument(input.ConfigurationFilePath)
+	if err != nil {
+		return nil, err
+	}
+	values := map[string]any{}
+	flattenDocument("", document, values)
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return &ListConfigurationValuesOutput{Keys: keys, Values: values}, nil
+}
+
+type ListConfigurationValuesInput struct {
+	ConfigurationFilePath string
+}
+
+type ListConfigurationValuesOutput struct {
+	Keys   []string
+	Values map[string]any
+}
@@ -0,0 +1,268 @@
+package usecase
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/yusadeol/go-commit/internal/domain/vo"
+)
+
+// Configuration files are named after these, followed by the extension of
+// their format, such as commit.yaml or .commit.toml.
+const (
+	ConfigurationFileBaseName           = "commit"
+	RepositoryConfigurationFileBaseName = ".commit"
+)
+
+// configurationEnvironmentVariables lists the variables that override the
+// configuration files. Lists are comma separated.
+var configurationEnvironmentVariables = []string{
+	"COMMIT_PROVIDER",
+	"COMMIT_LANGUAGE",
+	"COMMIT_MODEL",
+	"COMMIT_PROMPT",
+	"COMMIT_TYPES",
+	"COMMIT_SCOPES",
+	"COMMIT_EXCLUDES",
+	"COMMIT_BRANCH_PATTERN",
+	"COMMIT_TICKET_PATTERN",
+	"COMMIT_TICKET_PLACEMENT",
+	"COMMIT_TICKET_FOOTER_TOKEN",
+	"COMMIT_HISTORY_EXAMPLES_ENABLED",
+	"COMMIT_HISTORY_EXAMPLES_COUNT",
+}
+
+type LoadConfiguration struct{}
+
+func NewLoadConfiguration() *LoadConfiguration {
+	return &LoadConfiguration{}
+}
+
+// Execute layers the defaults, the global file, the selected profile, the
+// repository file and the COMMIT_* environment variables, each one overriding
+// the previous ones. CLI options are the last layer and are applied by the
+// commands themselves.
+func (l *LoadConfiguration) Execute(input *LoadConfigurationInput) (*LoadConfigurationOutput, error) {
+	configuration := NewDefaultConfiguration()
+	data, err := readMigratedConfigurationFile(input.ConfigurationFilePath)
+	if err != nil {
+		return nil, err
+	}
+	err = l.mergeGlobalConfiguration(configuration, data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration %s: %w", input.ConfigurationFilePath, err)
+	}
+	output := &LoadConfigurationOutput{Configuration: configuration}
+	output.Profile, err = l.selectProfile(configuration, input)
+	if err != nil {
+		return nil, err
+	}
+	if output.Profile != "" {
+		profile, exists := configuration.Profiles[output.Profile]
+		if !exists {
+			err = fmt.Errorf("profile %q not found", output.Profile)
+			if suggestion := vo.Suggest(output.Profile, slices.Sorted(maps.Keys(configuration.Profiles))); suggestion != "" {
+				err = fmt.Errorf("%w, did you mean %q?", err, suggestion)
+			}
+			return nil, err
+		}
+		configuration.ApplyProfile(profile)
+	}
+	if input.WorkingDirPath != "" {
+		repositoryConfigurationFilePath, err := l.findRepositoryConfigurationFile(input.WorkingDirPath)
+		if err != nil {
+			return nil, err
+		}
+		if repositoryConfigurationFilePath != "" {
+			repositoryConfiguration, err := l.readRepositoryConfiguration(repositoryConfigurationFilePath)
+			if err != nil {
+				return nil, err
+			}
+			configuration.Merge(repositoryConfiguration)
+			output.RepositoryConfigurationFilePath = repositoryConfigurationFilePath
+		}
+	}
+	if input.LookupEnv != nil {
+		err = l.mergeEnvironment(configuration, input.LookupEnv)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return output, nil
+}
+
+// mergeGlobalConfiguration decodes the global file over the defaults. Nested
+// objects are merged field by field, but AI providers and languages replace
+// the defaults entirely so entries can be removed.
+func (l *LoadConfiguration) mergeGlobalConfiguration(configuration *vo.Configuration, data []byte) error {
+	var fields map[string]json.RawMessage
+	err := json.Unmarshal(data, &fields)
+	if err != nil {
+		return err
+	}
+	if _, exists := fields["ai_providers"]; exists {
+		configuration.AIProviders = nil
+	}
+	if _, exists := fields["languages"]; exists {
+		configuration.Languages = nil
+	}
+	return decodeStrictly(data, configuration)
+}
+
+func (l *LoadConfiguration) mergeEnvironment(configuration *vo.Configuration, lookupEnv func(key string) (string, bool)) error {
+	for _, name := range configurationEnvironmentVariables {
+		value, exists := lookupEnv(name)
+		if !exists {
+			continue
+		}
+		err := l.applyEnvironmentVariable(configuration, name, value)
+		if err != nil {
+			return fmt.Errorf("invalid value for %s: %q", name, value)
+		}
+	}
+	return nil
+}
+
+func (l *LoadConfiguration) applyEnvironmentVariable(configuration *vo.Configuration, name string, value string) error {
+	var err error
+	switch name {
+	case "COMMIT_PROVIDER":
+		configuration.DefaultAIProvider = value
+	case "COMMIT_LANGUAGE":
+		configuration.DefaultLanguage = value
+	case "COMMIT_MODEL":
+		configuration.Model = value
+	case "COMMIT_PROMPT":
+		configuration.Prompt = value
+	case "COMMIT_TYPES":
+		configuration.Types = splitList(value)
+	case "COMMIT_SCOPES":
+		configuration.Scopes = splitList(value)
+	case "COMMIT_EXCLUDES":
+		configuration.Excludes = splitList(value)
+	case "COMMIT_BRANCH_PATTERN":
+		configuration.BranchPattern = value
+	case "COMMIT_TICKET_PATTERN":
+		configuration.Ticket.Pattern = value
+	case "COMMIT_TICKET_PLACEMENT":
+		configuration.Ticket.Placement = value
+	case "COMMIT_TICKET_FOOTER_TOKEN":
+		configuration.Ticket.FooterToken = value
+	case "COMMIT_HISTORY_EXAMPLES_ENABLED":
+		configuration.HistoryExamples.Enabled, err = strconv.ParseBool(value)
+	case "COMMIT_HISTORY_EXAMPLES_COUNT":
+		configuration.HistoryExamples.Count, err = strconv.Atoi(value)
+	}
+	return err
+}
+
+// selectProfile returns the profile given as an option, then the one in
+// COMMIT_PROFILE and finally the first rule matching the working directory.
+func (l *LoadConfiguration) selectProfile(configuration *vo.Configuration, input *LoadConfigurationInput) (string, error) {
+	if input.Profile != "" {
+		return input.Profile, nil
+	}
+	if input.LookupEnv != nil {
+		if profile, exists := input.LookupEnv("COMMIT_PROFILE"); exists && profile != "" {
+			return profile, nil
+		}
+	}
+	if input.WorkingDirPath == "" {
+		return "", nil
+	}
+	workingDirPath, err := filepath.Abs(input.WorkingDirPath)
+	if err != nil {
+		return "", err
+	}
+	for _, profileRule := range configuration.ProfileRules {
+		rulePath := profileRule.Path
+		if rulePath == "~" || strings.HasPrefix(rulePath, "~/") {
+			homeDirPath, err := os.UserHomeDir()
+			if err != nil {
+				return "", err
+			}
+			rulePath = filepath.Join(homeDirPath, strings.TrimPrefix(rulePath, "~"))
+		}
+		relativePath, err := filepath.Rel(rulePath, workingDirPath)
+		if err == nil && relativePath != ".." && !strings.HasPrefix(relativePath, ".."+string(filepath.Separator)) {
+			return profileRule.Profile, nil
+		}
+	}
+	return "", nil
+}
+
+// findRepositoryConfigurationFile walks up from dirPath to the git root and
+// returns the closest repository configuration file. Outside a repository no
+// file is used.
+func (l *LoadConfiguration) findRepositoryConfigurationFile(dirPath string) (string, error) {
+	dirPath, err := filepath.Abs(dirPath)
+	if err != nil {
+		return "", err
+	}
+	var closestFilePath string
+	for {
+		if closestFilePath == "" {
+			closestFilePath = findExistingConfigurationFile(dirPath, RepositoryConfigurationFileBaseName)
+		}
+		_, err = os.Stat(filepath.Join(dirPath, ".git"))
+		if err == nil {
+			return closestFilePath, nil
+		}
+		parentDirPath := filepath.Dir(dirPath)
+		if parentDirPath == dirPath {
+			return "", nil
+		}
+		dirPath = parentDirPath
+	}
+}
+
+func (l *LoadConfiguration) readRepositoryConfiguration(filePath string) (*vo.RepositoryConfiguration, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	document, err := decodeConfigurationData(filePath, data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid repository configuration %s: %w", filePath, err)
+	}
+	data, err = json.Marshal(document)
+	if err != nil {
+		return nil, err
+	}
+	var repositoryConfiguration vo.RepositoryConfiguration
+	err = decodeStrictly(data, &repositoryConfiguration)
+	if err != nil {
+		return nil, fmt.Errorf("invalid repository configuration %s: %w", filePath, err)
+	}
+	return &repositoryConfiguration, nil
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+type LoadConfigurationInput struct {
+	ConfigurationFilePath string
+	WorkingDirPath        string
+	Profile               string
+	LookupEnv             func(key string) (string, bool)
+}
+
+type LoadConfigurationOutput struct {
+	Configuration                   *vo.Configuration
+	RepositoryConfigurationFilePath string
+	Profile                         string
+}
@@ -0,0 +1,455 @@
+package usecase
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/yusadeol/go-commit/internal/domain/vo"
+)
+
+func writeTestFile(t *testing.T, filePath string, content string) {
+	t.Helper()
+	err := os.MkdirAll(filepath.Dir(filePath), 0700)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err = os.WriteFile(filePath, []byte(content), 0644)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func lookupEnvFrom(variables map[string]string) func(key string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, exists := variables[key]
+		return value, exists
+	}
+}
+
+func loadTestConfiguration(t *testing.T, input *LoadConfigurationInput) *LoadConfigurationOutput {
+	t.Helper()
+	output, err := NewLoadConfiguration().Execute(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return output
+}
+
+func TestLoadConfiguration(t *testing.T) {
+	t.Run("should merge the global file over the defaults", func(t *testing.T) {
+		configurationFilePath := filepath.Join(t.TempDir(), "commit.json")
+		writeTestFile(t, configurationFilePath, `{"schema_version": 1, "ticket": {"placement": "prefix"}, "types": ["feat"]}`)
+		configuration := loadTestConfiguration(t, &LoadConfigurationInput{ConfigurationFilePath: configurationFilePath}).Configuration
+		defaultConfiguration := NewDefaultConfiguration()
+		if configuration.Ticket.Placement != vo.TicketPlacementPrefix {
+			t.Errorf("expected placement %q, got: %q", vo.TicketPlacementPrefix, configuration.Ticket.Placement)
+		}
+		if configuration.Ticket.Pattern != defaultConfiguration.Ticket.Pattern {
+			t.Errorf("expected the default pattern %q, got: %q", defaultConfiguration.Ticket.Pattern, configuration.Ticket.Pattern)
+		}
+		if !slices.Equal(configuration.Types, []string{"feat"}) {
+			t.Errorf("expected types [feat], got: %v", configuration.Types)
+		}
+		if _, exists := configuration.AIProviders["openai"]; !exists {
+			t.Errorf("expected the default AI providers to be kept")
+		}
+	})
+
+	t.Run("should replace the AI providers and languages of the defaults", func(t *testing.T) {
+		configurationFilePath := filepath.Join(t.TempDir(), "commit.json")
+		writeTestFile(t, configurationFilePath, `{
+			"schema_version": 1,
+			"default_ai_provider": "gateway",
+			"default_language": "de_DE",
+			"ai_providers": {"gateway": {"id": "gateway", "models": ["m"], "default_model": "m"}},
+			"languages": {"de_DE": {"id": "de_DE", "display_name": "German"}}
+		}`)
+		configuration := loadTestConfiguration(t, &LoadConfigurationInput{ConfigurationFilePath: configurationFilePath}).Configuration
+		aiProviderIDs := slices.Sorted(maps.Keys(configuration.AIProviders))
+		if !slices.Equal(aiProviderIDs, []string{"gateway"}) {
+			t.Errorf("expected only the gateway AI provider, got: %v", aiProviderIDs)
+		}
+		if _, exists := configuration.Languages["en_US"]; exists || len(configuration.Languages) != 1 {
+			t.Errorf("expected only the de_DE language, got: %v", configuration.Languages)
+		}
+	})
+
+	t.Run("should return error when the global file is missing", func(t *testing.T) {
+		_, err := NewLoadConfiguration().Execute(&LoadConfigurationInput{
+			ConfigurationFilePath: filepath.Join(t.TempDir(), "commit.json"),
+		})
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
+
+func TestLoadConfigurationRepositoryFile(t *testing.T) {
+	newTestTree := func(t *testing.T) (string, string) {
+		t.Helper()
+		dirPath := t.TempDir()
+		configurationFilePath := filepath.Join(dirPath, "commit.json")
+		writeTestFile(t, configurationFilePath, `{"schema_version": 1, "default_language": "pt_BR", "prompt": "global"}`)
+		return dirPath, configurationFilePath
+	}
+
+	t.Run("should find the closest file walking up to the git root", func(t *testing.T) {
+		dirPath, configurationFilePath := newTestTree(t)
+		repositoryDirPath := filepath.Join(dirPath, "repository")
+		writeTestFile(t, filepath.Join(repositoryDirPath, ".git", "HEAD"), "ref: refs/heads/main\n")
+		writeTestFile(t, filepath.Join(repositoryDirPath, ".commit.yaml"), "default_language: es_ES\n")
+		workingDirPath := filepath.Join(repositoryDirPath, "internal", "app")
+		output := loadTestConfiguration(t, &LoadConfigurationInput{
+			ConfigurationFilePath: configurationFilePath,
+			WorkingDirPath:        workingDirPath,
+		})
+		if output.RepositoryConfigurationFilePath != filepath.Join(repositoryDirPath, ".commit.yaml") {
+			t.Errorf("expected the repository file to be found, got: %q", output.RepositoryConfigurationFilePath)
+		}
+		if output.Configuration.DefaultLanguage != "es_ES" {
+			t.Errorf("expected the repository file to override the global one, got: %q", output.Configuration.DefaultLanguage)
+		}
+		if output.Configuration.Prompt != "global" {
+			t.Errorf("expected the global prompt to be kept, got: %q", output.Configuration.Prompt)
+		}
+	})
+
+	t.Run("should ignore the file outside a repository", func(t *testing.T) {
+		dirPath, configurationFilePath := newTestTree(t)
+		writeTestFile(t, filepath.Join(dirPath, "project", ".commit.json"), `{"default_language": "es_ES"}`)
+		output := loadTestConfiguration(t, &LoadConfigurationInput{
+			ConfigurationFilePath: configurationFilePath,
+			WorkingDirPath:        filepath.Join(dirPath, "project"),
+		})
+		if output.RepositoryConfigurationFilePath != "" {
+			t.Errorf("expected no repository file, got: %q", output.RepositoryConfigurationFilePath)
+		}
+		if output.Configuration.DefaultLanguage != "pt_BR" {
+			t.Errorf("expected the global language, got: %q", output.Configuration.DefaultLanguage)
+		}
+	})
+
+	t.Run("should return error when the file sets AI provider settings", func(t *testing.T) {
+		dirPath, configurationFilePath := newTestTree(t)
+		writeTestFile(t, filepath.Join(dirPath, ".git", "HEAD"), "ref: refs/heads/main\n")
+		writeTestFile(t, filepath.Join(dirPath, ".commit.json"), `{"ai_providers": {}}`)
+		_, err := NewLoadConfiguration().Execute(&LoadConfigurationInput{
+			ConfigurationFilePath: configurationFilePath,
+			WorkingDirPath:        dirPath,
+		})
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
+
+func TestLoadConfigurationMigration(t *testing.T) {
+	t.Run("upgrades older files in place and keeps a backup", func(t *testing.T) {
+		configurationFilePath := filepath.Join(t.TempDir(), "commit.json")
+		legacyConfiguration := `{"default_ai_provider": "openai", "ai_providers": {"openai": {"default_model": "gpt-4.1"}}}`
+		writeTestFile(t, configurationFilePath, legacyConfiguration)
+		output := loadTestConfiguration(t, &LoadConfigurationInput{
+			ConfigurationFilePath: configurationFilePath,
+			WorkingDirPath:        t.TempDir(),
+		})
+		if output.Configuration.AIProviders["openai"].ID != "openai" {
+			t.Errorf("expected the provider ID to be filled, got: %+v", output.Configuration.AIProviders)
+		}
+		document, schemaVersion, err := readConfigurationFile(configurationFilePath)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if schemaVersion != vo.ConfigurationSchemaVersion || document["ai_providers"].(map[string]any)["openai"].(map[string]any)["id"] != "openai" {
+			t.Errorf("expected the file to be upgraded, got version %d: %v", schemaVersion, document)
+		}
+		backupFilePaths, err := filepath.Glob(configurationFilePath + ".v0.*.bak")
+		if err != nil || len(backupFilePaths) != 1 {
+			t.Fatalf("expected a single backup, got: %v, %v", backupFilePaths, err)
+		}
+		data, err := os.ReadFile(backupFilePaths[0])
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(data) != legacyConfiguration {
+			t.Errorf("expected the backup to keep the original, got: %q", data)
+		}
+	})
+
+	t.Run("leaves current files untouched", func(t *testing.T) {
+		configurationFilePath := filepath.Join(t.TempDir(), "commit.json")
+		currentConfiguration := "{\"schema_version\": 1, \"prompt\": \"keep\"}"
+		writeTestFile(t, configurationFilePath, currentConfiguration)
+		loadTestConfiguration(t, &LoadConfigurationInput{
+			ConfigurationFilePath: configurationFilePath,
+			WorkingDirPath:        t.TempDir(),
+		})
+		data, err := os.ReadFile(configurationFilePath)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(data) != currentConfiguration {
+			t.Errorf("expected the file to be left untouched, got: %q", data)
+		}
+		backupFilePaths, _ := filepath.Glob(configurationFilePath + ".*.bak")
+		if len(backupFilePaths) != 0 {
+			t.Errorf("expected no backup, got: %v", backupFilePaths)
+		}
+	})
+}
+
+func TestLoadConfigurationEnvironment(t *testing.T) {
+	configurationFilePath := filepath.Join(t.TempDir(), "commit.json")
+	writeTestFile(t, configurationFilePath, `{"schema_version": 1}`)
+	tests := []struct {
+		name     string
+		value    string
+		field    func(configuration *vo.Configuration) any
+		expected any
+	}{
+		{name: "COMMIT_PROVIDER", value: "anthropic", field: func(c *vo.Configuration) any { return c.DefaultAIProvider }, expected: "anthropic"},
+		{name: "COMMIT_LANGUAGE", value: "pt_BR", field: func(c *vo.Configuration) any { return c.DefaultLanguage }, expected: "pt_BR"},
+		{name: "COMMIT_MODEL", value: "gpt-4.1-mini", field: func(c *vo.Configuration) any { return c.Model }, expected: "gpt-4.1-mini"},
+		{name: "COMMIT_PROMPT", value: "Be brief", field: func(c *vo.Configuration) any { return c.Prompt }, expected: "Be brief"},
+		{name: "COMMIT_TYPES", value: "feat, fix,,docs ", field: func(c *vo.Configuration) any { return c.Types }, expected: []string{"feat", "fix", "docs"}},
+		{name: "COMMIT_SCOPES", value: "api,cli", field: func(c *vo.Configuration) any { return c.Scopes }, expected: []string{"api", "cli"}},
+		{name: "COMMIT_EXCLUDES", value: "*.lock", field: func(c *vo.Configuration) any { return c.Excludes }, expected: []string{"*.lock"}},
+		{name: "COMMIT_BRANCH_PATTERN", value: "{type}/{slug}", field: func(c *vo.Configuration) any { return c.BranchPattern }, expected: "{type}/{slug}"},
+		{name: "COMMIT_TICKET_PATTERN", value: "#[0-9]+", field: func(c *vo.Configuration) any { return c.Ticket.Pattern }, expected: "#[0-9]+"},
+		{name: "COMMIT_TICKET_PLACEMENT", value: "prefix", field: func(c *vo.Configuration) any { return c.Ticket.Placement }, expected: "prefix"},
+		{name: "COMMIT_TICKET_FOOTER_TOKEN", value: "Closes", field: func(c *vo.Configuration) any { return c.Ticket.FooterToken }, expected: "Closes"},
+		{name: "COMMIT_HISTORY_EXAMPLES_ENABLED", value: "false", field: func(c *vo.Configuration) any { return c.HistoryExamples.Enabled }, expected: false},
+		{name: "COMMIT_HISTORY_EXAMPLES_COUNT", value: "8", field: func(c *vo.Configuration) any { return c.HistoryExamples.Count }, expected: 8},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			configuration := loadTestConfiguration(t, &LoadConfigurationInput{
+				ConfigurationFilePath: configurationFilePath,
+				LookupEnv:             lookupEnvFrom(map[string]string{test.name: test.value}),
+			}).Configuration
+			value := test.field(configuration)
+			if list, isList := test.expected.([]string); isList {
+				if !slices.Equal(value.([]string), list) {
+					t.Errorf("expected %v, got: %v", list, value)
+				}
+				return
+			}
+			if value != test.expected {
+				t.Errorf("expected %v, got: %v", test.expected, value)
+			}
+		})
+	}
+
+	for _, name := range []string{"COMMIT_HISTORY_EXAMPLES_ENABLED", "COMMIT_HISTORY_EXAMPLES_COUNT"} {
+		t.Run(name+" returns error when the value is invalid", func(t *testing.T) {
+			_, err := NewLoadConfiguration().Execute(&LoadConfigurationInput{
+				ConfigurationFilePath: configurationFilePath,
+				LookupEnv:             lookupEnvFrom(map[string]string{name: "many"}),
+			})
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+	}
+
+	t.Run("should override the repository file", func(t *testing.T) {
+		dirPath := t.TempDir()
+		writeTestFile(t, filepath.Join(dirPath, ".git", "HEAD"), "ref: refs/heads/main\n")
+		writeTestFile(t, filepath.Join(dirPath, ".commit.json"), `{"default_language": "es_ES"}`)
+		configuration := loadTestConfiguration(t, &LoadConfigurationInput{
+			ConfigurationFilePath: configurationFilePath,
+			WorkingDirPath:        dirPath,
+			LookupEnv:             lookupEnvFrom(map[string]string{"COMMIT_LANGUAGE": "pt_BR"}),
+		}).Configuration
+		if configuration.DefaultLanguage != "pt_BR" {
+			t.Errorf("expected %q, got: %q", "pt_BR", configuration.DefaultLanguage)
+		}
+	})
+}
+
+func TestResolveConfigurationFilePath(t *testing.T) {
+	homeDirPath := t.TempDir()
+	t.Setenv("HOME", homeDirPath)
+	xdgConfigDirPath := t.TempDir()
+	writeTestFile(t, filepath.Join(xdgConfigDirPath, "commit.toml"), "schema_version = 1\n")
+	tests := []struct {
+		name         string
+		configOption string
+		variables    map[string]string
+		expected     string
+	}{
+		{name: "option", configOption: "team.json", variables: map[string]string{"COMMIT_CONFIG": "env.json"}, expected: "team.json"},
+		{name: "COMMIT_CONFIG", variables: map[string]string{"COMMIT_CONFIG": "env.json", "XDG_CONFIG_HOME": xdgConfigDirPath}, expected: "env.json"},
+		{name: "XDG_CONFIG_HOME with an existing format", variables: map[string]string{"XDG_CONFIG_HOME": xdgConfigDirPath}, expected: filepath.Join(xdgConfigDirPath, "commit.toml")},
+		{name: "relative XDG_CONFIG_HOME", variables: map[string]string{"XDG_CONFIG_HOME": "config"}, expected: filepath.Join(homeDirPath, ".config", "commit.json")},
+		{name: "home directory", variables: map[string]string{}, expected: filepath.Join(homeDirPath, ".config", "commit.json")},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output, err := NewResolveConfigurationFilePath().Execute(&ResolveConfigurationFilePathInput{
+				ConfigOption: test.configOption,
+				LookupEnv:    lookupEnvFrom(test.variables),
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if output.ConfigurationFilePath != test.expected {
+				t.Errorf("expected %q, got: %q", test.expected, output.ConfigurationFilePath)
+			}
+		})
+	}
+}
+
+func TestLoadConfigurationProfiles(t *testing.T) {
+	homeDirPath := t.TempDir()
+	t.Setenv("HOME", homeDirPath)
+	configurationFilePath := filepath.Join(t.TempDir(), "commit.json")
+	writeTestFile(t, configurationFilePath, `{
+		"schema_version": 1,
+		"prompt": "global",
+		"profiles": {
+			"work": {"default_language": "pt_BR", "prompt": "work"},
+			"oss": {"default_language": "es_ES"}
+		},
+		"profile_rules": [{"path": "~/work", "profile": "work"}]
+	}`)
+	tests := []struct {
+		name             string
+		profile          string
+		variables        map[string]string
+		workingDirPath   string
+		expectedProfile  string
+		expectedLanguage string
+	}{
+		{
+			name:             "option over variable and directory",
+			profile:          "oss",
+			variables:        map[string]string{"COMMIT_PROFILE": "work"},
+			workingDirPath:   filepath.Join(homeDirPath, "work"),
+			expectedProfile:  "oss",
+			expectedLanguage: "es_ES",
+		},
+		{
+			name:             "variable over directory",
+			variables:        map[string]string{"COMMIT_PROFILE": "oss"},
+			workingDirPath:   filepath.Join(homeDirPath, "work"),
+			expectedProfile:  "oss",
+			expectedLanguage: "es_ES",
+		},
+		{
+			name:             "empty variable falls back to the directory",
+			variables:        map[string]string{"COMMIT_PROFILE": ""},
+			workingDirPath:   filepath.Join(homeDirPath, "work"),
+			expectedProfile:  "work",
+			expectedLanguage: "pt_BR",
+		},
+		{
+			name:             "directory of the rule",
+			workingDirPath:   filepath.Join(homeDirPath, "work"),
+			expectedProfile:  "work",
+			expectedLanguage: "pt_BR",
+		},
+		{
+			name:             "subdirectory of the rule",
+			workingDirPath:   filepath.Join(homeDirPath, "work", "api", "internal"),
+			expectedProfile:  "work",
+			expectedLanguage: "pt_BR",
+		},
+		{
+			name:             "sibling sharing the prefix",
+			workingDirPath:   filepath.Join(homeDirPath, "workspace"),
+			expectedLanguage: "en_US",
+		},
+		{
+			name:             "parent of the rule",
+			workingDirPath:   homeDirPath,
+			expectedLanguage: "en_US",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output := loadTestConfiguration(t, &LoadConfigurationInput{
+				ConfigurationFilePath: configurationFilePath,
+				WorkingDirPath:        test.workingDirPath,
+				Profile:               test.profile,
+				LookupEnv:             lookupEnvFrom(test.variables),
+			})
+			if output.Profile != test.expectedProfile {
+				t.Errorf("expected profile %q, got: %q", test.expectedProfile, output.Profile)
+			}
+			if output.Configuration.DefaultLanguage != test.expectedLanguage {
+				t.Errorf("expected language %q, got: %q", test.expectedLanguage, output.Configuration.DefaultLanguage)
+			}
+		})
+	}
+
+	t.Run("should return error with a suggestion when the profile is unknown", func(t *testing.T) {
+		_, err := NewLoadConfiguration().Execute(&LoadConfigurationInput{
+			ConfigurationFilePath: configurationFilePath,
+			Profile:               "wrok",
+		})
+		if err == nil || err.Error() != `profile "wrok" not found, did you mean "work"?` {
+			t.Fatalf("expected a suggestion error, got: %v", err)
+		}
+	})
+
+	t.Run("should apply the profile below the repository file and the variables", func(t *testing.T) {
+		repositoryDirPath := filepath.Join(homeDirPath, "work", "api")
+		writeTestFile(t, filepath.Join(repositoryDirPath, ".git", "HEAD"), "ref: refs/heads/main\n")
+		writeTestFile(t, filepath.Join(repositoryDirPath, ".commit.json"), `{"default_language": "en_US"}`)
+		configuration := loadTestConfiguration(t, &LoadConfigurationInput{
+			ConfigurationFilePath: configurationFilePath,
+			WorkingDirPath:        repositoryDirPath,
+		}).Configuration
+		if configuration.DefaultLanguage != "en_US" || configuration.Prompt != "work" {
+			t.Errorf("expected the repository language and the profile prompt, got: %q and %q", configuration.DefaultLanguage, configuration.Prompt)
+		}
+		configuration = loadTestConfiguration(t, &LoadConfigurationInput{
+			ConfigurationFilePath: configurationFilePath,
+			WorkingDirPath:        repositoryDirPath,
+			LookupEnv:             lookupEnvFrom(map[string]string{"COMMIT_PROMPT": "env"}),
+		}).Configuration
+		if configuration.Prompt != "env" {
+			t.Errorf("expected the variable to override the profile, got: %q", configuration.Prompt)
+		}
+	})
+}
+
+func TestLoadConfigurationProfileProviders(t *testing.T) {
+	configurationFilePath := filepath.Join(t.TempDir(), "commit.json")
+	writeTestFile(t, configurationFilePath, `{
+		"schema_version": 1,
+		"ai_providers": {"openai": {"id": "openai", "api_key_env": "OPENAI_API_KEY", "models": ["gpt-4.1"], "default_model": "gpt-4.1"}},
+		"profiles": {
+			"work": {
+				"ai_providers": {"openai": {"api_key_command": "vault read gateway", "models": ["internal"], "default_model": "internal"}},
+				"ticket": {"placement": "prefix"},
+				"history_examples": {"count": 2}
+			}
+		}
+	}`)
+	configuration := loadTestConfiguration(t, &LoadConfigurationInput{
+		ConfigurationFilePath: configurationFilePath,
+		Profile:               "work",
+	}).Configuration
+	aiProvider := configuration.AIProviders["openai"]
+	if aiProvider.ID != "openai" || aiProvider.DefaultModel != "internal" || aiProvider.APIKeyCommand != "vault read gateway" {
+		t.Errorf("expected the profile provider, got: %+v", aiProvider)
+	}
+	if aiProvider.APIKeyEnv != "" {
+		t.Errorf("expected the provider to be replaced as a whole, got api_key_env %q", aiProvider.APIKeyEnv)
+	}
+	defaultConfiguration := NewDefaultConfiguration()
+	if configuration.Ticket.Placement != vo.TicketPlacementPrefix || configuration.Ticket.Pattern != defaultConfiguration.Ticket.Pattern {
+		t.Errorf("expected only the ticket placement to change, got: %+v", configuration.Ticket)
+	}
+	if configuration.HistoryExamples.Count != 2 || !configuration.HistoryExamples.Enabled {
+		t.Errorf("expected only the history examples count to change, got: %+v", configuration.HistoryExamples)
+	}
+	configuration = loadTestConfiguration(t, &LoadConfigurationInput{ConfigurationFilePath: configurationFilePath}).Configuration
+	if configuration.AIProviders["openai"].DefaultModel != "gpt-4.1" {
+		t.Errorf("expected the global provider without a profile, got: %+v", configuration.AIProviders["openai"])
+	}
+}
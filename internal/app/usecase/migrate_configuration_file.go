@@ -0,0 +1,40 @@
+package usecase
+
+import "github.com/yusadeol/go-commit/internal/domain/vo"
+
+type MigrateConfigurationFile struct{}
+
+func NewMigrateConfigurationFile() *MigrateConfigurationFile {
+	return &MigrateConfigurationFile{}
+}
+
+// Execute rewrites a file written by an older release with the current
+// schema version, after backing it up. Current files are left untouched.
+func (m *MigrateConfigurationFile) Execute(input *MigrateConfigurationFileInput) (*MigrateConfigurationFileOutput, error) {
+	document, schemaVersion, err := readConfigurationFile(input.ConfigurationFilePath)
+	if err != nil {
+		return nil, err
+	}
+	output := &MigrateConfigurationFileOutput{
+		FromSchemaVersion: schemaVersion,
+		ToSchemaVersion:   vo.ConfigurationSchemaVersion,
+	}
+	if schemaVersion == vo.ConfigurationSchemaVersion {
+		return output, nil
+	}
+	output.BackupFilePath, err = writeConfigurationDocument(input.ConfigurationFilePath, document)
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+type MigrateConfigurationFileInput struct {
+	ConfigurationFilePath string
+}
+
+type MigrateConfigurationFileOutput struct {
+	FromSchemaVersion int
+	ToSchemaVersion   int
+	BackupFilePath    string
+}
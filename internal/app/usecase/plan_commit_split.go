@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/yusadeol/go-commit/internal/domain/vo"
+	"github.com/yusadeol/go-commit/internal/infra/service/ai"
+	"github.com/yusadeol/go-commit/internal/infra/service/git"
+)
+
+var ErrInvalidCommitSplitPlan = errors.New("invalid commit split plan")
+
+type PlanCommitSplit struct{}
+
+func NewPlanCommitSplit() *PlanCommitSplit {
+	return &PlanCommitSplit{}
+}
+
+func (p *PlanCommitSplit) Execute(input *PlanCommitSplitInput) (*PlanCommitSplitOutput, error) {
+	stagedFiles, err := input.Git.StagedFiles()
+	if err != nil {
+		return nil, err
+	}
+	if len(stagedFiles) == 0 {
+		return nil, errors.New("no staged changes found")
+	}
+	groups := [][]string{stagedFiles}
+	if len(stagedFiles) > 1 {
+		groups, err = p.groupFiles(input, stagedFiles)
+		if err != nil {
+			return nil, err
+		}
+	}
+	generate := NewGenerate()
+	commitGroups := make([]CommitGroup, 0, len(groups))
+	for _, files := range groups {
+		diff, err := input.Git.Diff(append([]string{"--staged", "--"}, files...)...)
+		if err != nil {
+			return nil, err
+		}
+		output, err := generate.Execute(&GenerateInput{
+			AIDefaultProviderFactory: input.AIDefaultProviderFactory,
+			AIProvider:               input.AIProvider,
+			Language:                 input.Language,
+			Diff:                     diff,
+			Ticket:                   input.Ticket,
+			Branch:                   input.Branch,
+			Examples:                 input.Examples,
+			Prompt:                   input.Prompt,
+			Types:                    input.Types,
+			Scopes:                   input.Scopes,
+		})
+		if err != nil {
+			return nil, err
+		}
+		commitGroups = append(commitGroups, CommitGroup{Files: files, Commit: output.Commit})
+	}
+	return &PlanCommitSplitOutput{Groups: commitGroups}, nil
+}
+
+func (p *PlanCommitSplit) groupFiles(input *PlanCommitSplitInput, stagedFiles []string) ([][]string, error) {
+	aiProvider, err := input.AIDefaultProviderFactory.Create(input.AIProvider.ID, input.AIProvider.APIKey)
+	if err != nil {
+		return nil, err
+	}
+	instructions := `
+		Group the files of this diff into coherent logical commits.
+		EVERY file must belong to exactly one group.
+		Order the groups so each commit builds on the previous ones.
+		ONLY return a JSON array of arrays of file paths, without any additional text or explanation.
+		For example: [["internal/user.go", "internal/user_test.go"], ["README.md"]]
+	`
+	diff, err := input.Git.Diff("--staged", "--no-renames")
+	if err != nil {
+		return nil, err
+	}
+	output, err := aiProvider.Ask(&ai.ProviderInput{
+		Model:        input.AIProvider.DefaultModel,
+		Instructions: instructions,
+		Input:        diff,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var groups [][]string
+	err = json.Unmarshal([]byte(p.stripCodeFence(output.Text)), &groups)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCommitSplitPlan, err)
+	}
+	return p.validateGroups(groups, stagedFiles)
+}
+
+// validateGroups rejects unknown or repeated files and appends the files the
+// model forgot as a final group, so no staged change is ever lost.
+func (p *PlanCommitSplit) validateGroups(groups [][]string, stagedFiles []string) ([][]string, error) {
+	remainingFiles := make(map[string]bool, len(stagedFiles))
+	for _, stagedFile := range stagedFiles {
+		remainingFiles[stagedFile] = true
+	}
+	validGroups := make([][]string, 0, len(groups)+1)
+	for _, group := range groups {
+		if len(group) == 0 {
+			continue
+		}
+		for _, file := range group {
+			if !remainingFiles[file] {
+				return nil, fmt.Errorf("%w: unexpected or repeated file %q", ErrInvalidCommitSplitPlan, file)
+			}
+			delete(remainingFiles, file)
+		}
+		validGroups = append(validGroups, group)
+	}
+	var missingFiles []string
+	for _, stagedFile := range stagedFiles {
+		if remainingFiles[stagedFile] {
+			missingFiles = append(missingFiles, stagedFile)
+		}
+	}
+	if len(missingFiles) > 0 {
+		validGroups = append(validGroups, missingFiles)
+	}
+	return validGroups, nil
+}
+
+func (p *PlanCommitSplit) stripCodeFence(text string) string {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, "```") {
+		return text
+	}
+	text = strings.TrimPrefix(text, "```json")
+	text = strings.TrimPrefix(text, "```")
+	return strings.TrimSpace(strings.TrimSuffix(text, "```"))
+}
+
+type CommitGroup struct {
+	Files  []string
+	Commit string
+}
+
+// PlanCommitSplitInput holds the same settings as GenerateInput, which are
+// applied to the message of every group.
+type PlanCommitSplitInput struct {
+	AIDefaultProviderFactory ai.ProviderFactory
+	AIProvider               *vo.AIProvider
+	Language                 *vo.Language
+	Git                      *git.Git
+	Ticket                   *vo.Ticket
+	Branch                   string
+	Examples                 []string
+	Prompt                   string
+	Types                    []string
+	Scopes                   []string
+}
+
+type PlanCommitSplitOutput struct {
+	Groups []CommitGroup
+}
@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"os"
+	"path/filepath"
+)
+
+type ResolveConfigurationFilePath struct{}
+
+func NewResolveConfigurationFilePath() *ResolveConfigurationFilePath {
+	return &ResolveConfigurationFilePath{}
+}
+
+// Execute resolves the configuration file from the --config option, then
+// COMMIT_CONFIG, then $XDG_CONFIG_HOME and finally ~/.config, where
+// commit.json, commit.yaml, commit.yml and commit.toml are tried in order.
+func (r *ResolveConfigurationFilePath) Execute(input *ResolveConfigurationFilePathInput) (*ResolveConfigurationFilePathOutput, error) {
+	if input.ConfigOption != "" {
+		return &ResolveConfigurationFilePathOutput{ConfigurationFilePath: input.ConfigOption}, nil
+	}
+	if configurationFilePath, _ := input.LookupEnv("COMMIT_CONFIG"); configurationFilePath != "" {
+		return &ResolveConfigurationFilePathOutput{ConfigurationFilePath: configurationFilePath}, nil
+	}
+	configurationDirPath, _ := input.LookupEnv("XDG_CONFIG_HOME")
+	if !filepath.IsAbs(configurationDirPath) {
+		homeDirPath, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		configurationDirPath = filepath.Join(homeDirPath, ".config")
+	}
+	return &ResolveConfigurationFilePathOutput{
+		ConfigurationFilePath: FindConfigurationFile(configurationDirPath, ConfigurationFileBaseName),
+	}, nil
+}
+
+type ResolveConfigurationFilePathInput struct {
+	ConfigOption string
+	LookupEnv    func(key string) (string, bool)
+}
+
+type ResolveConfigurationFilePathOutput struct {
+	ConfigurationFilePath string
+}
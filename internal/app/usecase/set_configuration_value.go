@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type SetConfigurationValue struct{}
+
+func NewSetConfigurationValue() *SetConfigurationValue {
+	return &SetConfigurationValue{}
+}
+
+// Execute stores the value as JSON when it parses as such, such as true, 5 or
+// ["a"], and falls back to a plain string when the key expects one. null is
+// rejected rather than removing the key, which is left to unset.
+func (s *SetConfigurationValue) Execute(input *SetConfigurationValueInput) (*SetConfigurationValueOutput, error) {
+	var typedValue any
+	err := json.Unmarshal([]byte(input.Value), &typedValue)
+	if err == nil && typedValue == nil {
+		return nil, fmt.Errorf("invalid value for %s: null, unset the key to remove it", input.Key)
+	}
+	if err == nil {
+		err = s.setValue(input, typedValue)
+		if err == nil {
+			return &SetConfigurationValueOutput{Value: typedValue}, nil
+		}
+	}
+	err = s.setValue(input, input.Value)
+	if err != nil {
+		return nil, err
+	}
+	return &SetConfigurationValueOutput{Value: input.Value}, nil
+}
+
+func (s *SetConfigurationValue) setValue(input *SetConfigurationValueInput, value any) error {
+	document, err := readConfigurationDocument(input.ConfigurationFilePath)
+	if err != nil {
+		return err
+	}
+	err = setDocumentValue(document, input.Key, value)
+	if err != nil {
+		return err
+	}
+	_, err = decodeConfigurationDocument(document)
+	if err != nil {
+		return fmt.Errorf("invalid value for %s: %w", input.Key, err)
+	}
+	_, err = writeConfigurationDocument(input.ConfigurationFilePath, document)
+	return err
+}
+
+type SetConfigurationValueInput struct {
+	ConfigurationFilePath string
+	Key                   string
+	Value                 string
+}
+
+type SetConfigurationValueOutput struct {
+	Value any
+}
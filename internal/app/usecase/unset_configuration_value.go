@@ -0,0 +1,25 @@
+package usecase
+
+type UnsetConfigurationValue struct{}
+
+func NewUnsetConfigurationValue() *UnsetConfigurationValue {
+	return &UnsetConfigurationValue{}
+}
+
+func (u *UnsetConfigurationValue) Execute(input *UnsetConfigurationValueInput) error {
+	document, err := readConfigurationDocument(input.ConfigurationFilePath)
+	if err != nil {
+		return err
+	}
+	err = setDocumentValue(document, input.Key, nil)
+	if err != nil {
+		return err
+	}
+	_, err = writeConfigurationDocument(input.ConfigurationFilePath, document)
+	return err
+}
+
+type UnsetConfigurationValueInput struct {
+	ConfigurationFilePath string
+	Key                   string
+}
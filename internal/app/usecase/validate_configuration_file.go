@@ -0,0 +1,23 @@
+package usecase
+
+type ValidateConfigurationFile struct{}
+
+func NewValidateConfigurationFile() *ValidateConfigurationFile {
+	return &ValidateConfigurationFile{}
+}
+
+func (v *ValidateConfigurationFile) Execute(input *ValidateConfigurationFileInput) error {
+	document, err := readConfigurationDocument(input.ConfigurationFilePath)
+	if err != nil {
+		return err
+	}
+	configuration, err := decodeConfigurationDocument(document)
+	if err != nil {
+		return err
+	}
+	return configuration.Validate()
+}
+
+type ValidateConfigurationFileInput struct {
+	ConfigurationFilePath string
+}
@@ -0,0 +1,46 @@
+package vo
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	DefaultBranchPattern = "<type>/<ticket>-<slug>"
+	maxBranchSlugLength  = 50
+)
+
+var (
+	branchSlugInvalidCharactersPattern = regexp.MustCompile(`[^a-z0-9]+`)
+	branchSeparatorsPattern            = regexp.MustCompile(`[-_.]*/[-_.]*`)
+	branchRepeatedDashesPattern        = regexp.MustCompile(`-{2,}`)
+)
+
+// NewBranchName fills the <type>, <ticket> and <slug> placeholders of
+// pattern, dropping the separators left around empty placeholders.
+func NewBranchName(pattern string, commitType string, ticket string, description string) string {
+	if pattern == "" {
+		pattern = DefaultBranchPattern
+	}
+	branchName := strings.NewReplacer(
+		"<type>", commitType,
+		"<ticket>", ticket,
+		"<slug>", Slugify(description),
+	).Replace(pattern)
+	branchName = branchSeparatorsPattern.ReplaceAllString(branchName, "/")
+	branchName = branchRepeatedDashesPattern.ReplaceAllString(branchName, "-")
+	return strings.Trim(branchName, "-_./")
+}
+
+func Slugify(text string) string {
+	slug := branchSlugInvalidCharactersPattern.ReplaceAllString(strings.ToLower(text), "-")
+	slug = strings.Trim(slug, "-")
+	if len(slug) <= maxBranchSlugLength {
+		return slug
+	}
+	slug = slug[:maxBranchSlugLength]
+	if index := strings.LastIndex(slug, "-"); index > 0 {
+		slug = slug[:index]
+	}
+	return slug
+}
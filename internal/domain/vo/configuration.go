@@ -1,20 +1,295 @@
 package vo
 
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var ErrConfigurationNotFound = errors.New("configuration file not found")
+
+// ConfigurationSchemaVersion is the version written by this release. Files
+// without a schema_version are version 0.
+const ConfigurationSchemaVersion = 1
+
+const (
+	TicketPlacementFooter = "footer"
+	TicketPlacementPrefix = "prefix"
+)
+
 type Configuration struct {
+	Schema            string                `json:"$schema,omitempty"`
+	SchemaVersion     int                   `json:"schema_version"`
 	DefaultAIProvider string                `json:"default_ai_provider"`
 	DefaultLanguage   string                `json:"default_language"`
+	Model             string                `json:"model"`
 	AIProviders       map[string]AIProvider `json:"ai_providers"`
 	Languages         map[string]Language   `json:"languages"`
+	BranchPattern     string                `json:"branch_pattern"`
+	Ticket            Ticket                `json:"ticket"`
+	HistoryExamples   HistoryExamples       `json:"history_examples"`
+	Prompt            string                `json:"prompt"`
+	Types             []string              `json:"types"`
+	Scopes            []string              `json:"scopes"`
+	Excludes          []string              `json:"excludes"`
+	Profiles          map[string]Profile    `json:"profiles,omitempty"`
+	ProfileRules      []ProfileRule         `json:"profile_rules,omitempty"`
+}
+
+// Profile is a named set of overrides, such as a work or an open source
+// setup. Only the fields present are applied. Each AI provider replaces the
+// global one with the same ID as a whole, or is added next to them.
+type Profile struct {
+	DefaultAIProvider *string                 `json:"default_ai_provider"`
+	DefaultLanguage   *string                 `json:"default_language"`
+	Model             *string                 `json:"model"`
+	AIProviders       map[string]AIProvider   `json:"ai_providers"`
+	BranchPattern     *string                 `json:"branch_pattern"`
+	Ticket            *ProfileTicket          `json:"ticket"`
+	HistoryExamples   *ProfileHistoryExamples `json:"history_examples"`
+	Prompt            *string                 `json:"prompt"`
+	Types             *[]string               `json:"types"`
+	Scopes            *[]string               `json:"scopes"`
+	Excludes          *[]string               `json:"excludes"`
+}
+
+// ProfileTicket and ProfileHistoryExamples override single fields of the
+// ticket and history examples settings.
+type ProfileTicket struct {
+	Pattern     *string `json:"pattern"`
+	Placement   *string `json:"placement"`
+	FooterToken *string `json:"footer_token"`
+}
+
+type ProfileHistoryExamples struct {
+	Enabled *bool `json:"enabled"`
+	Count   *int  `json:"count"`
+}
+
+// ProfileRule selects a profile for every directory under Path, which may
+// start with ~ for the home directory.
+type ProfileRule struct {
+	Path    string `json:"path"`
+	Profile string `json:"profile"`
+}
+
+// RepositoryConfiguration holds the fields a repository may override. It has
+// no AI provider settings, so secrets such as API keys stay global.
+type RepositoryConfiguration struct {
+	Schema            string    `json:"$schema,omitempty"`
+	DefaultAIProvider *string   `json:"default_ai_provider"`
+	DefaultLanguage   *string   `json:"default_language"`
+	Prompt            *string   `json:"prompt"`
+	Types             *[]string `json:"types"`
+	Scopes            *[]string `json:"scopes"`
+	Excludes          *[]string `json:"excludes"`
+}
+
+// Validate checks that the defaults refer to existing entries and returns
+// every problem found.
+func (c *Configuration) Validate() error {
+	var errs []error
+	if _, exists := c.AIProviders[c.DefaultAIProvider]; !exists {
+		errs = append(errs, fmt.Errorf("default_ai_provider %q is not in ai_providers", c.DefaultAIProvider))
+	}
+	if _, exists := c.Languages[c.DefaultLanguage]; !exists {
+		errs = append(errs, fmt.Errorf("default_language %q is not in languages", c.DefaultLanguage))
+	}
+	for _, id := range slices.Sorted(maps.Keys(c.AIProviders)) {
+		aiProvider := c.AIProviders[id]
+		if aiProvider.DefaultModel != "" && !slices.Contains(aiProvider.Models, aiProvider.DefaultModel) {
+			errs = append(errs, fmt.Errorf("ai_providers.%s.default_model %q is not in models", id, aiProvider.DefaultModel))
+		}
+	}
+	errs = append(errs, validateTicket("ticket", c.Ticket)...)
+	for _, name := range slices.Sorted(maps.Keys(c.Profiles)) {
+		profile := c.Profiles[name]
+		for _, id := range slices.Sorted(maps.Keys(profile.AIProviders)) {
+			aiProvider := profile.AIProviders[id]
+			if aiProvider.DefaultModel != "" && !slices.Contains(aiProvider.Models, aiProvider.DefaultModel) {
+				errs = append(errs, fmt.Errorf("profiles.%s.ai_providers.%s.default_model %q is not in models", name, id, aiProvider.DefaultModel))
+			}
+		}
+		if profile.DefaultAIProvider != nil {
+			_, existsGlobally := c.AIProviders[*profile.DefaultAIProvider]
+			_, existsInProfile := profile.AIProviders[*profile.DefaultAIProvider]
+			if !existsGlobally && !existsInProfile {
+				errs = append(errs, fmt.Errorf("profiles.%s.default_ai_provider %q is not in ai_providers", name, *profile.DefaultAIProvider))
+			}
+		}
+		if profile.Ticket != nil {
+			ticket := c.Ticket
+			profile.Ticket.applyTo(&ticket)
+			errs = append(errs, validateTicket("profiles."+name+".ticket", ticket)...)
+		}
+		if profile.DefaultLanguage != nil {
+			if _, exists := c.Languages[*profile.DefaultLanguage]; !exists {
+				errs = append(errs, fmt.Errorf("profiles.%s.default_language %q is not in languages", name, *profile.DefaultLanguage))
+			}
+		}
+	}
+	for index, profileRule := range c.ProfileRules {
+		if !isProfileRulePath(profileRule.Path) {
+			errs = append(errs, fmt.Errorf("profile_rules[%d].path %q must be absolute or start with ~", index, profileRule.Path))
+		}
+		if _, exists := c.Profiles[profileRule.Profile]; !exists {
+			errs = append(errs, fmt.Errorf("profile_rules[%d].profile %q is not in profiles", index, profileRule.Profile))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func validateTicket(key string, ticket Ticket) []error {
+	var errs []error
+	if ticket.Placement != "" && ticket.Placement != TicketPlacementFooter && ticket.Placement != TicketPlacementPrefix {
+		errs = append(errs, fmt.Errorf("%s.placement %q must be %q or %q", key, ticket.Placement, TicketPlacementFooter, TicketPlacementPrefix))
+	}
+	if _, err := regexp.Compile(ticket.Pattern); err != nil {
+		errs = append(errs, fmt.Errorf("%s.pattern %q is not a valid regular expression", key, ticket.Pattern))
+	}
+	return errs
+}
+
+// isProfileRulePath accepts absolute paths and paths in the home directory,
+// as a relative path would depend on where commit is run.
+func isProfileRulePath(path string) bool {
+	return filepath.IsAbs(path) || path == "~" || strings.HasPrefix(path, "~/")
+}
+
+// Merge replaces every field set in the repository configuration. Lists are
+// replaced as a whole rather than appended to.
+func (c *Configuration) Merge(repositoryConfiguration *RepositoryConfiguration) {
+	if repositoryConfiguration.DefaultAIProvider != nil {
+		c.DefaultAIProvider = *repositoryConfiguration.DefaultAIProvider
+	}
+	if repositoryConfiguration.DefaultLanguage != nil {
+		c.DefaultLanguage = *repositoryConfiguration.DefaultLanguage
+	}
+	if repositoryConfiguration.Prompt != nil {
+		c.Prompt = *repositoryConfiguration.Prompt
+	}
+	if repositoryConfiguration.Types != nil {
+		c.Types = *repositoryConfiguration.Types
+	}
+	if repositoryConfiguration.Scopes != nil {
+		c.Scopes = *repositoryConfiguration.Scopes
+	}
+	if repositoryConfiguration.Excludes != nil {
+		c.Excludes = *repositoryConfiguration.Excludes
+	}
+}
+
+// ApplyProfile replaces every field set in the profile. Lists are replaced as
+// a whole rather than appended to.
+func (c *Configuration) ApplyProfile(profile Profile) {
+	if profile.DefaultAIProvider != nil {
+		c.DefaultAIProvider = *profile.DefaultAIProvider
+	}
+	if profile.DefaultLanguage != nil {
+		c.DefaultLanguage = *profile.DefaultLanguage
+	}
+	if profile.Model != nil {
+		c.Model = *profile.Model
+	}
+	if profile.AIProviders != nil {
+		aiProviders := maps.Clone(c.AIProviders)
+		if aiProviders == nil {
+			aiProviders = map[string]AIProvider{}
+		}
+		for id, aiProvider := range profile.AIProviders {
+			if aiProvider.ID == "" {
+				aiProvider.ID = id
+			}
+			aiProviders[id] = aiProvider
+		}
+		c.AIProviders = aiProviders
+	}
+	if profile.BranchPattern != nil {
+		c.BranchPattern = *profile.BranchPattern
+	}
+	if profile.Ticket != nil {
+		profile.Ticket.applyTo(&c.Ticket)
+	}
+	if profile.HistoryExamples != nil {
+		if profile.HistoryExamples.Enabled != nil {
+			c.HistoryExamples.Enabled = *profile.HistoryExamples.Enabled
+		}
+		if profile.HistoryExamples.Count != nil {
+			c.HistoryExamples.Count = *profile.HistoryExamples.Count
+		}
+	}
+	if profile.Prompt != nil {
+		c.Prompt = *profile.Prompt
+	}
+	if profile.Types != nil {
+		c.Types = *profile.Types
+	}
+	if profile.Scopes != nil {
+		c.Scopes = *profile.Scopes
+	}
+	if profile.Excludes != nil {
+		c.Excludes = *profile.Excludes
+	}
+}
+
+func (p *ProfileTicket) applyTo(ticket *Ticket) {
+	if p.Pattern != nil {
+		ticket.Pattern = *p.Pattern
+	}
+	if p.Placement != nil {
+		ticket.Placement = *p.Placement
+	}
+	if p.FooterToken != nil {
+		ticket.FooterToken = *p.FooterToken
+	}
 }
 
 type AIProvider struct {
-	ID           string   `json:"id"`
-	APIKey       string   `json:"api_key"`
-	Models       []string `json:"models"`
-	DefaultModel string   `json:"default_model"`
+	ID            string   `json:"id"`
+	APIKey        string   `json:"api_key"`
+	APIKeyEnv     string   `json:"api_key_env"`
+	APIKeyCommand string   `json:"api_key_command"`
+	APIKeyKeyring bool     `json:"api_key_keyring"`
+	Models        []string `json:"models"`
+	DefaultModel  string   `json:"default_model"`
 }
 
 type Language struct {
 	ID          string `json:"id"`
 	DisplayName string `json:"display_name"`
 }
+
+type Ticket struct {
+	Pattern     string `json:"pattern"`
+	Placement   string `json:"placement"`
+	FooterToken string `json:"footer_token"`
+}
+
+type HistoryExamples struct {
+	Enabled bool `json:"enabled"`
+	Count   int  `json:"count"`
+}
+
+// Extract returns the first match of the ticket pattern in branch, or its
+// first capture group when the pattern has one.
+func (t *Ticket) Extract(branch string) (string, error) {
+	if t.Pattern == "" {
+		return "", nil
+	}
+	pattern, err := regexp.Compile(t.Pattern)
+	if err != nil {
+		return "", fmt.Errorf("invalid ticket pattern %q: %w", t.Pattern, err)
+	}
+	matches := pattern.FindStringSubmatch(branch)
+	if len(matches) == 0 {
+		return "", nil
+	}
+	if len(matches) > 1 {
+		return matches[1], nil
+	}
+	return matches[0], nil
+}
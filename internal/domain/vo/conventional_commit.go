@@ -0,0 +1,128 @@
+package vo
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+var ErrInvalidConventionalCommit = errors.New("invalid conventional commit")
+
+var (
+	conventionalCommitHeaderPattern = regexp.MustCompile(`^([a-zA-Z]+)(?:\(([^()\r\n]+)\))?(!)?: (\S.*)$`)
+	conventionalCommitFooterPattern = regexp.MustCompile(`^(BREAKING CHANGE|BREAKING-CHANGE|[A-Za-z][\w-]*)(: | #)(.*)$`)
+)
+
+type ConventionalCommit struct {
+	Type        string
+	Scope       string
+	Breaking    bool
+	Description string
+	Body        string
+	Footers     []Footer
+}
+
+type Footer struct {
+	Token     string
+	Separator string
+	Value     string
+}
+
+func ParseConventionalCommit(message string) (*ConventionalCommit, error) {
+	message = strings.TrimSpace(strings.ReplaceAll(message, "\r\n", "\n"))
+	header, rest, _ := strings.Cut(message, "\n")
+	matches := conventionalCommitHeaderPattern.FindStringSubmatch(strings.TrimSpace(header))
+	if matches == nil {
+		return nil, ErrInvalidConventionalCommit
+	}
+	conventionalCommit := &ConventionalCommit{
+		Type:        strings.ToLower(matches[1]),
+		Scope:       matches[2],
+		Breaking:    matches[3] == "!",
+		Description: strings.TrimSpace(matches[4]),
+	}
+	paragraphs := strings.Split(strings.TrimSpace(rest), "\n\n")
+	if len(paragraphs) > 0 {
+		footers, isFooter := parseFooters(paragraphs[len(paragraphs)-1])
+		if isFooter {
+			conventionalCommit.Footers = footers
+			paragraphs = paragraphs[:len(paragraphs)-1]
+		}
+	}
+	conventionalCommit.Body = strings.TrimSpace(strings.Join(paragraphs, "\n\n"))
+	for _, footer := range conventionalCommit.Footers {
+		if footer.IsBreakingChange() {
+			conventionalCommit.Breaking = true
+		}
+	}
+	return conventionalCommit, nil
+}
+
+func parseFooters(paragraph string) ([]Footer, bool) {
+	lines := strings.Split(paragraph, "\n")
+	if !conventionalCommitFooterPattern.MatchString(lines[0]) {
+		return nil, false
+	}
+	var footers []Footer
+	for _, line := range lines {
+		matches := conventionalCommitFooterPattern.FindStringSubmatch(line)
+		if matches != nil {
+			footers = append(footers, Footer{Token: matches[1], Separator: matches[2], Value: matches[3]})
+			continue
+		}
+		footers[len(footers)-1].Value += "\n" + line
+	}
+	return footers, true
+}
+
+func (f Footer) String() string {
+	separator := f.Separator
+	if separator == "" {
+		separator = ": "
+	}
+	return f.Token + separator + f.Value
+}
+
+func (f Footer) IsBreakingChange() bool {
+	return f.Token == "BREAKING CHANGE" || f.Token == "BREAKING-CHANGE"
+}
+
+// BreakingChange returns the BREAKING CHANGE footer value, falling back to
+// the description when the change is only flagged with "!".
+func (c *ConventionalCommit) BreakingChange() string {
+	for _, footer := range c.Footers {
+		if footer.IsBreakingChange() {
+			return footer.Value
+		}
+	}
+	if c.Breaking {
+		return c.Description
+	}
+	return ""
+}
+
+func (c *ConventionalCommit) Header() string {
+	header := c.Type
+	if c.Scope != "" {
+		header += "(" + c.Scope + ")"
+	}
+	if c.Breaking {
+		header += "!"
+	}
+	return header + ": " + c.Description
+}
+
+func (c *ConventionalCommit) String() string {
+	parts := []string{c.Header()}
+	if c.Body != "" {
+		parts = append(parts, c.Body)
+	}
+	if len(c.Footers) > 0 {
+		footers := make([]string, 0, len(c.Footers))
+		for _, footer := range c.Footers {
+			footers = append(footers, footer.String())
+		}
+		parts = append(parts, strings.Join(footers, "\n"))
+	}
+	return strings.Join(parts, "\n\n")
+}
@@ -6,6 +6,7 @@ const (
 	ExitCodeSuccess           ExitCode = 0
 	ExitCodeError             ExitCode = 1
 	ExitCodeInvalidUsage      ExitCode = 2
+	ExitCodeConfiguration     ExitCode = 78
 	ExitCodeCommandNotFound   ExitCode = 127
 	ExitCodePermissionDenied  ExitCode = 126
 	ExitCodeInterruptedByUser ExitCode = 130
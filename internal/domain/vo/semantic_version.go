@@ -0,0 +1,163 @@
+package vo
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidSemanticVersion = errors.New("invalid semantic version")
+
+var semanticVersionPattern = regexp.MustCompile(`^(v?)(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-([0-9A-Za-z.-]+))?$`)
+
+type VersionBump int
+
+const (
+	VersionBumpNone VersionBump = iota
+	VersionBumpPatch
+	VersionBumpMinor
+	VersionBumpMajor
+)
+
+func (v VersionBump) String() string {
+	switch v {
+	case VersionBumpPatch:
+		return "patch"
+	case VersionBumpMinor:
+		return "minor"
+	case VersionBumpMajor:
+		return "major"
+	}
+	return "none"
+}
+
+type SemanticVersion struct {
+	Prefix     string
+	Major      int
+	Minor      int
+	Patch      int
+	PreRelease string
+}
+
+func ParseSemanticVersion(version string) (*SemanticVersion, error) {
+	matches := semanticVersionPattern.FindStringSubmatch(strings.TrimSpace(version))
+	if matches == nil {
+		return nil, ErrInvalidSemanticVersion
+	}
+	major, _ := strconv.Atoi(matches[2])
+	minor, _ := strconv.Atoi(matches[3])
+	patch, _ := strconv.Atoi(matches[4])
+	return &SemanticVersion{
+		Prefix:     matches[1],
+		Major:      major,
+		Minor:      minor,
+		Patch:      patch,
+		PreRelease: matches[5],
+	}, nil
+}
+
+func (s *SemanticVersion) String() string {
+	version := fmt.Sprintf("%s%d.%d.%d", s.Prefix, s.Major, s.Minor, s.Patch)
+	if s.PreRelease != "" {
+		version += "-" + s.PreRelease
+	}
+	return version
+}
+
+func (s *SemanticVersion) IsPreRelease() bool {
+	return s.PreRelease != ""
+}
+
+// PreReleaseChannel splits a pre-release such as "rc.2" into its channel
+// and number. The number is zero when the pre-release is not numbered.
+func (s *SemanticVersion) PreReleaseChannel() (string, int) {
+	channel, number, found := strings.Cut(s.PreRelease, ".")
+	if !found {
+		return channel, 0
+	}
+	parsedNumber, err := strconv.Atoi(number)
+	if err != nil {
+		return s.PreRelease, 0
+	}
+	return channel, parsedNumber
+}
+
+// Bump returns the next release version, dropping any pre-release.
+func (s *SemanticVersion) Bump(bump VersionBump) *SemanticVersion {
+	next := &SemanticVersion{Prefix: s.Prefix, Major: s.Major, Minor: s.Minor, Patch: s.Patch}
+	switch bump {
+	case VersionBumpMajor:
+		next.Major++
+		next.Minor = 0
+		next.Patch = 0
+	case VersionBumpMinor:
+		next.Minor++
+		next.Patch = 0
+	case VersionBumpPatch:
+		next.Patch++
+	}
+	return next
+}
+
+func (s *SemanticVersion) SameRelease(other *SemanticVersion) bool {
+	return s.Major == other.Major && s.Minor == other.Minor && s.Patch == other.Patch
+}
+
+// Compare returns -1, 0 or 1 following semver precedence, where a
+// pre-release sorts before its release.
+func (s *SemanticVersion) Compare(other *SemanticVersion) int {
+	for _, pair := range [][2]int{{s.Major, other.Major}, {s.Minor, other.Minor}, {s.Patch, other.Patch}} {
+		if pair[0] != pair[1] {
+			if pair[0] < pair[1] {
+				return -1
+			}
+			return 1
+		}
+	}
+	if s.PreRelease == other.PreRelease {
+		return 0
+	}
+	if s.PreRelease == "" {
+		return 1
+	}
+	if other.PreRelease == "" {
+		return -1
+	}
+	return comparePreRelease(s.PreRelease, other.PreRelease)
+}
+
+func comparePreRelease(preRelease string, other string) int {
+	identifiers := strings.Split(preRelease, ".")
+	otherIdentifiers := strings.Split(other, ".")
+	for index := 0; index < len(identifiers) && index < len(otherIdentifiers); index++ {
+		if identifiers[index] == otherIdentifiers[index] {
+			continue
+		}
+		number, err := strconv.Atoi(identifiers[index])
+		otherNumber, otherErr := strconv.Atoi(otherIdentifiers[index])
+		switch {
+		case err == nil && otherErr == nil:
+			if number < otherNumber {
+				return -1
+			}
+			return 1
+		case err == nil:
+			return -1
+		case otherErr == nil:
+			return 1
+		case identifiers[index] < otherIdentifiers[index]:
+			return -1
+		default:
+			return 1
+		}
+	}
+	switch {
+	case len(identifiers) < len(otherIdentifiers):
+		return -1
+	case len(identifiers) > len(otherIdentifiers):
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,41 @@
+package vo
+
+import "strings"
+
+// Suggest returns the candidate closest to value, ignoring case, or an empty
+// string when none is close enough to be a likely typo.
+func Suggest(value string, candidates []string) string {
+	maxDistance := max(2, len(value)/3)
+	suggestion := ""
+	for _, candidate := range candidates {
+		distance := editDistance(strings.ToLower(value), strings.ToLower(candidate))
+		if distance <= maxDistance {
+			maxDistance = distance - 1
+			suggestion = candidate
+		}
+	}
+	return suggestion
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a string, b string) int {
+	source := []rune(a)
+	target := []rune(b)
+	previous := make([]int, len(target)+1)
+	current := make([]int, len(target)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(source); i++ {
+		current[0] = i
+		for j := 1; j <= len(target); j++ {
+			cost := 1
+			if source[i-1] == target[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(target)]
+}
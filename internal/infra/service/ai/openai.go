@@ -49,7 +49,7 @@ func (o *OpenAI) Ask(input *ProviderInput) (*ProviderOutput, error) {
 	if len(parsedResponseBody.Output) > 0 && len(parsedResponseBody.Output[0].Content) > 0 {
 		text = parsedResponseBody.Output[0].Content[0].Text
 	}
-	return &ProviderOutput{Status: parsedResponseBody.Status, Text: text}, nil
+	return &ProviderOutput{Status: parsedResponseBody.Status, Text: text, Usage: parsedResponseBody.Usage}, nil
 }
 
 type apiResponse struct {
@@ -59,4 +59,5 @@ type apiResponse struct {
 			Text string `json:"text"`
 		} `json:"content"`
 	} `json:"output"`
+	Usage TokenUsage `json:"usage"`
 }
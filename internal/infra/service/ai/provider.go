@@ -19,6 +19,15 @@ type ProviderInput struct {
 type ProviderOutput struct {
 	Status string
 	Text   string
+	Usage  TokenUsage
+}
+
+// TokenUsage is the number of tokens billed for a request, when the provider
+// reports it.
+type TokenUsage struct {
+	InputTokens  int `json:"input_tokens"`
+	OutputTokens int `json:"output_tokens"`
+	TotalTokens  int `json:"total_tokens"`
 }
 
 type ProviderFactory interface {
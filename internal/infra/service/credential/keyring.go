@@ -0,0 +1,154 @@
+package credential
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+const keyringService = "go-commit"
+
+type Keyring interface {
+	Get(account string) (string, error)
+	Set(account string, secret string) error
+}
+
+// NewDefaultKeyring uses the macOS keychain or the Secret Service when they
+// are available, falling back to a credentials file for headless systems.
+func NewDefaultKeyring(fallbackFilePath string) Keyring {
+	fileKeyring := NewFileKeyring(fallbackFilePath)
+	if runtime.GOOS == "darwin" {
+		return &systemKeyring{
+			lookupArgs: func(account string) []string {
+				return []string{"security", "find-generic-password", "-s", keyringService, "-a", account, "-w"}
+			},
+			// security -i reads the command from stdin, so the secret is not
+			// shown in the process list as an argument would be.
+			storeCommand: func(account string, secret string) *exec.Cmd {
+				cmd := exec.Command("security", "-i")
+				cmd.Stdin = strings.NewReader(fmt.Sprintf(
+					"add-generic-password -U -s %s -a %s -w %s\n",
+					quoteSecurityArg(keyringService), quoteSecurityArg(account), quoteSecurityArg(secret),
+				))
+				return cmd
+			},
+			fallback: fileKeyring,
+		}
+	}
+	_, err := exec.LookPath("secret-tool")
+	if err == nil && os.Getenv("DBUS_SESSION_BUS_ADDRESS") != "" {
+		return &systemKeyring{
+			lookupArgs: func(account string) []string {
+				return []string{"secret-tool", "lookup", "service", keyringService, "account", account}
+			},
+			storeCommand: func(account string, secret string) *exec.Cmd {
+				cmd := exec.Command("secret-tool", "store", "--label=commit "+account, "service", keyringService, "account", account)
+				cmd.Stdin = strings.NewReader(secret)
+				return cmd
+			},
+			fallback: fileKeyring,
+		}
+	}
+	return fileKeyring
+}
+
+// quoteSecurityArg quotes an argument of a security -i command line.
+func quoteSecurityArg(arg string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(arg) + `"`
+}
+
+type systemKeyring struct {
+	lookupArgs   func(account string) []string
+	storeCommand func(account string, secret string) *exec.Cmd
+	fallback     Keyring
+}
+
+func (s *systemKeyring) Get(account string) (string, error) {
+	secret := s.lookup(account)
+	if secret == "" {
+		return s.fallback.Get(account)
+	}
+	return secret, nil
+}
+
+// Set reads the secret back after storing it, as security -i may exit
+// successfully even when the command it reads from stdin fails.
+func (s *systemKeyring) Set(account string, secret string) error {
+	err := s.storeCommand(account, secret).Run()
+	if err != nil || s.lookup(account) != strings.TrimSpace(secret) {
+		return s.fallback.Set(account, secret)
+	}
+	return nil
+}
+
+func (s *systemKeyring) lookup(account string) string {
+	var out bytes.Buffer
+	args := s.lookupArgs(account)
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdout = &out
+	err := cmd.Run()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(out.String())
+}
+
+// FileKeyring stores secrets in a JSON file that only its owner can read.
+type FileKeyring struct {
+	filePath string
+}
+
+func NewFileKeyring(filePath string) *FileKeyring {
+	return &FileKeyring{filePath: filePath}
+}
+
+func (f *FileKeyring) Get(account string) (string, error) {
+	secrets, err := f.read()
+	if err != nil {
+		return "", err
+	}
+	return secrets[account], nil
+}
+
+func (f *FileKeyring) Set(account string, secret string) error {
+	secrets, err := f.read()
+	if err != nil {
+		return err
+	}
+	secrets[account] = secret
+	data, err := json.MarshalIndent(secrets, "", "    ")
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(f.filePath), 0700)
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(f.filePath, append(data, '\n'), 0600)
+	if err != nil {
+		return err
+	}
+	return os.Chmod(f.filePath, 0600)
+}
+
+func (f *FileKeyring) read() (map[string]string, error) {
+	secrets := map[string]string{}
+	data, err := os.ReadFile(f.filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return secrets, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(data, &secrets)
+	if err != nil {
+		return nil, err
+	}
+	return secrets, nil
+}
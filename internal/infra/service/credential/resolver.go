@@ -0,0 +1,78 @@
+package credential
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/yusadeol/go-commit/internal/domain/vo"
+)
+
+var ErrAPIKeyNotFound = errors.New("API key not found")
+
+type Resolver interface {
+	Resolve(aiProvider *vo.AIProvider) (string, error)
+}
+
+type DefaultResolver struct {
+	keyring Keyring
+}
+
+func NewDefaultResolver(keyring Keyring) *DefaultResolver {
+	return &DefaultResolver{keyring: keyring}
+}
+
+// Resolve returns the API key from the first configured source: environment
+// variable, credential command, keyring and then the plaintext api_key.
+func (d *DefaultResolver) Resolve(aiProvider *vo.AIProvider) (string, error) {
+	if aiProvider.APIKeyEnv != "" {
+		apiKey := os.Getenv(aiProvider.APIKeyEnv)
+		if apiKey == "" {
+			return "", fmt.Errorf("%w: environment variable %s is empty", ErrAPIKeyNotFound, aiProvider.APIKeyEnv)
+		}
+		return apiKey, nil
+	}
+	if aiProvider.APIKeyCommand != "" {
+		return d.runAPIKeyCommand(aiProvider.APIKeyCommand)
+	}
+	if aiProvider.APIKeyKeyring {
+		apiKey, err := d.keyring.Get(aiProvider.ID)
+		if err != nil {
+			return "", err
+		}
+		if apiKey == "" {
+			return "", fmt.Errorf("%w: no %q entry in the keyring", ErrAPIKeyNotFound, aiProvider.ID)
+		}
+		return apiKey, nil
+	}
+	return aiProvider.APIKey, nil
+}
+
+func (d *DefaultResolver) runAPIKeyCommand(command string) (string, error) {
+	var out bytes.Buffer
+	var outErr bytes.Buffer
+	cmd := exec.Command("sh", "-c", command)
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", command)
+	}
+	cmd.Stdout = &out
+	cmd.Stderr = &outErr
+	err := cmd.Run()
+	if err != nil {
+		if outErr.Len() > 0 {
+			return "", fmt.Errorf("api_key_command failed: %s", strings.TrimSpace(outErr.String()))
+		}
+		return "", fmt.Errorf("api_key_command failed: %w", err)
+	}
+	// Tools such as pass print the secret on the first line followed by
+	// metadata, so only that line is used.
+	apiKey, _, _ := strings.Cut(strings.TrimSpace(out.String()), "\n")
+	if apiKey == "" {
+		return "", fmt.Errorf("%w: api_key_command printed nothing", ErrAPIKeyNotFound)
+	}
+	return strings.TrimSpace(apiKey), nil
+}
@@ -0,0 +1,238 @@
+package git
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+const (
+	fieldSeparator  = "\x1f"
+	recordSeparator = "\x1e"
+)
+
+type Git struct {
+	dirPath string
+}
+
+func New(dirPath string) *Git {
+	return &Git{dirPath: dirPath}
+}
+
+type Commit struct {
+	Hash    string
+	Message string
+}
+
+func (g *Git) Run(args ...string) (string, error) {
+	var out bytes.Buffer
+	var outErr bytes.Buffer
+	cmd := exec.Command("git", args...)
+	cmd.Dir = g.dirPath
+	cmd.Stdout = &out
+	cmd.Stderr = &outErr
+	err := cmd.Run()
+	if err != nil {
+		if outErr.Len() > 0 {
+			return "", errors.New(strings.TrimSpace(outErr.String()))
+		}
+		return "", err
+	}
+	return out.String(), nil
+}
+
+// Log returns the commits in revisionRange, newest first. An empty
+// revisionRange lists the whole history reachable from HEAD.
+func (g *Git) Log(revisionRange string) ([]Commit, error) {
+	if revisionRange == "" {
+		return g.log()
+	}
+	return g.log(revisionRange)
+}
+
+// RecentCommits returns up to limit commits reachable from HEAD, newest
+// first.
+func (g *Git) RecentCommits(limit int) ([]Commit, error) {
+	return g.log(fmt.Sprintf("--max-count=%d", limit))
+}
+
+func (g *Git) log(args ...string) ([]Commit, error) {
+	args = append([]string{"log", "--format=%H" + fieldSeparator + "%B" + recordSeparator}, args...)
+	out, err := g.Run(args...)
+	if err != nil {
+		return nil, err
+	}
+	var commits []Commit
+	for _, record := range strings.Split(out, recordSeparator) {
+		record = strings.TrimLeft(record, "\n")
+		if record == "" {
+			continue
+		}
+		parts := strings.SplitN(record, fieldSeparator, 2)
+		if len(parts) != 2 {
+			continue
+		}
+		commits = append(commits, Commit{Hash: parts[0], Message: strings.TrimSpace(parts[1])})
+	}
+	return commits, nil
+}
+
+// LatestTag returns the most recent tag reachable from ref, or an empty
+// string when there is none.
+func (g *Git) LatestTag(ref string) string {
+	out, err := g.Run("describe", "--tags", "--abbrev=0", ref)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(out)
+}
+
+// ExactTag returns the tag pointing at ref, or an empty string when ref is
+// not tagged.
+func (g *Git) ExactTag(ref string) string {
+	out, err := g.Run("describe", "--tags", "--exact-match", ref)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(out)
+}
+
+func (g *Git) CommitDate(ref string) (string, error) {
+	out, err := g.Run("log", "-1", "--format=%cs", ref)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// Tags returns the tags reachable from ref, or every tag when ref is empty.
+func (g *Git) Tags(ref string) ([]string, error) {
+	args := []string{"tag"}
+	if ref != "" {
+		args = append(args, "--merged", ref)
+	}
+	out, err := g.Run(args...)
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(out), nil
+}
+
+func (g *Git) CreateAnnotatedTag(name string, message string) error {
+	_, err := g.Run("tag", "-a", name, "-m", message)
+	return err
+}
+
+func (g *Git) Diff(args ...string) (string, error) {
+	return g.Run(append([]string{"diff"}, args...)...)
+}
+
+// CheckBranchName validates name with git check-ref-format and returns the
+// branch name it expands to.
+func (g *Git) CheckBranchName(name string) (string, error) {
+	out, err := g.Run("check-ref-format", "--branch", name)
+	if err != nil {
+		return "", fmt.Errorf("invalid branch name %q", name)
+	}
+	return strings.TrimSpace(out), nil
+}
+
+func (g *Git) SwitchCreate(branch string) error {
+	_, err := g.Run("switch", "-c", branch)
+	return err
+}
+
+// StagedFiles returns the paths with staged changes, listing renames as a
+// deletion and an addition.
+func (g *Git) StagedFiles() ([]string, error) {
+	out, err := g.Run("diff", "--staged", "--name-only", "--no-renames")
+	if err != nil {
+		return nil, err
+	}
+	out = strings.TrimSpace(out)
+	if out == "" {
+		return nil, nil
+	}
+	return strings.Split(out, "\n"), nil
+}
+
+func (g *Git) RevParse(ref string) (string, error) {
+	out, err := g.Run("rev-parse", "--verify", ref)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// HeadCommit returns the hash of HEAD, which is empty on a branch that has
+// no commits yet.
+func (g *Git) HeadCommit() (string, error) {
+	out, err := g.Run("rev-parse", "--verify", "--quiet", "HEAD")
+	if err == nil {
+		return strings.TrimSpace(out), nil
+	}
+	_, symbolicRefErr := g.Run("symbolic-ref", "--quiet", "HEAD")
+	if symbolicRefErr != nil {
+		return "", err
+	}
+	return "", nil
+}
+
+// WriteTree saves the index as a tree object and returns its hash.
+func (g *Git) WriteTree() (string, error) {
+	out, err := g.Run("write-tree")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+func (g *Git) ReadTree(tree string) error {
+	_, err := g.Run("read-tree", tree)
+	return err
+}
+
+// ClearIndex unstages every change on a branch without commits, where there
+// is no HEAD to reset the index to.
+func (g *Git) ClearIndex() error {
+	_, err := g.Run("read-tree", "--empty")
+	return err
+}
+
+// DeleteRef removes ref, or the branch it points to when it is symbolic,
+// such as HEAD.
+func (g *Git) DeleteRef(ref string) error {
+	_, err := g.Run("update-ref", "-d", ref)
+	return err
+}
+
+// ResetIndex unstages every change, or only paths when given, copying the
+// entries from treeish. The working tree is left untouched.
+func (g *Git) ResetIndex(treeish string, paths ...string) error {
+	args := []string{"reset", "--quiet", treeish}
+	if len(paths) > 0 {
+		args = append(append(args, "--"), paths...)
+	}
+	_, err := g.Run(args...)
+	return err
+}
+
+func (g *Git) ResetSoft(ref string) error {
+	_, err := g.Run("reset", "--quiet", "--soft", ref)
+	return err
+}
+
+func (g *Git) Commit(message string) error {
+	_, err := g.Run("commit", "-m", message)
+	return err
+}
+
+func (g *Git) CurrentBranch() (string, error) {
+	out, err := g.Run("rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}